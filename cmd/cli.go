@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/fatih/color"
 	"github.com/hashicorp/logutils"
@@ -84,7 +86,21 @@ func (cli *CLI) Run(args []string) int {
 		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; %w", err), map[string][]byte{})
 		return ExitCodeError
 	}
-	dir, filterFiles, err := processArgs(args[1:])
+	for _, pattern := range opts.Excludes {
+		if err := tflint.ValidatePattern(pattern); err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; %w", err), map[string][]byte{})
+			return ExitCodeError
+		}
+	}
+	var dirs []string
+	var filterFiles []string
+	if opts.Recursive {
+		dirs, err = findRootModuleDirs(args[1:])
+	} else {
+		var dir string
+		dir, filterFiles, err = processArgs(args[1:])
+		dirs = []string{dir}
+	}
 	if err != nil {
 		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI arguments; %w", err), map[string][]byte{})
 		return ExitCodeError
@@ -95,13 +111,107 @@ func (cli *CLI) Run(args []string) int {
 		return cli.printVersion(opts)
 	case opts.Init:
 		return cli.init(opts)
+	case opts.Explain != "":
+		return cli.explain(opts)
+	case opts.ListRules:
+		return cli.listRules(opts)
 	case opts.Langserver:
 		return cli.startLanguageServer(opts.Config, opts.toConfig())
 	default:
-		return cli.inspect(opts, dir, filterFiles)
+		if opts.TemplateFile != "" {
+			tmpl, err := loadTemplate(opts.TemplateFile)
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to load template file; %w", err), map[string][]byte{})
+				return ExitCodeError
+			}
+			cli.formatter.Template = tmpl
+		}
+		for _, spec := range opts.Outputs {
+			if strings.HasPrefix(spec, "template:") && cli.formatter.Template == nil {
+				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; `%s` requires --template-file", spec), map[string][]byte{})
+				return ExitCodeError
+			}
+		}
+
+		if opts.Jobs < 1 {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; --jobs must be a positive number, got %d", opts.Jobs), map[string][]byte{})
+			return ExitCodeError
+		}
+		log.Printf("[DEBUG] Checking rules with %d jobs", opts.Jobs)
+
+		if opts.Watch {
+			return cli.watch(opts, dirs, filterFiles)
+		}
+
+		outputs, closeOutputs, err := openOutputs(opts.Outputs)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; %w", err), map[string][]byte{})
+			return ExitCodeError
+		}
+		defer closeOutputs()
+		cli.formatter.Outputs = outputs
+
+		return cli.inspect(opts, dirs, filterFiles)
 	}
 }
 
+var outputFormats = []string{"default", "json", "checkstyle", "junit", "compact", "sarif", "github", "gitlab", "template"}
+
+// loadTemplate reads the template file for the `template` format.
+func loadTemplate(path string) (*template.Template, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("`%s` is not found", path)
+		}
+		return nil, err
+	}
+	return formatter.NewTemplate(filepath.Base(path), string(text))
+}
+
+// openOutputs parses `--output` options like `sarif:results.sarif` and creates the files.
+// The returned function closes all of the files.
+func openOutputs(specs []string) ([]*formatter.Output, func(), error) {
+	outputs := []*formatter.Output{}
+	files := []*os.File{}
+	closeFiles := func() {
+		for _, file := range files {
+			if err := file.Close(); err != nil {
+				log.Printf("[ERROR] Failed to close %s; %s", file.Name(), err)
+			}
+		}
+	}
+
+	for _, spec := range specs {
+		format, path, found := strings.Cut(spec, ":")
+		if !found || path == "" {
+			closeFiles()
+			return nil, nil, fmt.Errorf("`%s` is invalid output. The output must be in the form of `FORMAT:PATH`", spec)
+		}
+		valid := false
+		for _, f := range outputFormats {
+			if format == f {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			closeFiles()
+			return nil, nil, fmt.Errorf("%s is invalid format. Allowed formats are: %s", format, strings.Join(outputFormats, ", "))
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			closeFiles()
+			return nil, nil, fmt.Errorf("Failed to create `%s`; %w", path, err)
+		}
+		files = append(files, file)
+		outputs = append(outputs, &formatter.Output{Format: format, Writer: file})
+	}
+
+	return outputs, closeFiles, nil
+}
+
 func processArgs(args []string) (string, []string, error) {
 	if len(args) == 0 {
 		return ".", []string{}, nil
@@ -145,6 +255,58 @@ func processArgs(args []string) (string, []string, error) {
 	return dir, filterFiles, nil
 }
 
+// findRootModuleDirs walks the passed directories and returns every directory
+// that contains Terraform configuration files. Each of them is inspected as a root module.
+// Hidden directories such as `.terraform` and `.git` are skipped.
+func findRootModuleDirs(args []string) ([]string, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	dirs := []string{}
+	found := map[string]bool{}
+
+	for _, arg := range args {
+		fileInfo, err := os.Stat(arg)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return dirs, fmt.Errorf("Failed to load `%s`: File not found", arg)
+			}
+			return dirs, fmt.Errorf("Failed to load `%s`: %s", arg, err)
+		}
+		if !fileInfo.IsDir() {
+			return dirs, fmt.Errorf("Failed to load `%s`: Only directories are allowed in recursive mode", arg)
+		}
+
+		err = filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != arg && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".tf") && !strings.HasSuffix(path, ".tf.json") {
+				return nil
+			}
+
+			dir := filepath.Dir(path)
+			if !found[dir] {
+				found[dir] = true
+				dirs = append(dirs, dir)
+			}
+			return nil
+		})
+		if err != nil {
+			return dirs, fmt.Errorf("Failed to walk `%s`: %s", arg, err)
+		}
+	}
+
+	return dirs, nil
+}
+
 func unknownOptionHandler(option string, arg flags.SplitArgument, args []string) ([]string, error) {
 	if option == "debug" {
 		return []string{}, errors.New("`debug` option was removed in v0.8.0. Please set `TFLINT_LOG` environment variables instead")
@@ -2,16 +2,24 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/formatter"
+	"github.com/terraform-linters/tflint/plugin"
 	"github.com/terraform-linters/tflint/rules"
 	"github.com/terraform-linters/tflint/terraform/configs"
 	"github.com/terraform-linters/tflint/terraform/terraform"
@@ -253,6 +261,26 @@ func (r *errorRule) Check(runner *tflint.Runner) error {
 	return errors.New("Check failed")
 }
 
+type warningRule struct {
+	testRule
+}
+
+func (r *warningRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *warningRule) Check(runner *tflint.Runner) error {
+	runner.EmitIssue(
+		r,
+		"This is test warning",
+		hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 1},
+		},
+	)
+	return nil
+}
+
 func TestCLIRun__issuesFound(t *testing.T) {
 	cases := []struct {
 		Name    string
@@ -283,6 +311,26 @@ func TestCLIRun__issuesFound(t *testing.T) {
 			Status:  ExitCodeIssuesFound,
 			Stdout:  "This is test error (test_rule)",
 		},
+		{
+			Name:    "`--minimum-failure-severity` option with errors",
+			Command: "./tflint --minimum-failure-severity=warning",
+			Rule:    &testRule{},
+			Status:  ExitCodeIssuesFound,
+			Stdout:  fmt.Sprintf("%s (test_rule)", color.New(color.Bold).Sprint("This is test error")),
+		},
+		{
+			Name:    "`--minimum-failure-severity` option with warnings",
+			Command: "./tflint --minimum-failure-severity=error",
+			Rule:    &warningRule{},
+			Status:  ExitCodeOK,
+			Stdout:  fmt.Sprintf("%s (test_rule)", color.New(color.Bold).Sprint("This is test warning")),
+		},
+		{
+			Name:    "`--minimum-severity` option",
+			Command: "./tflint --minimum-severity=error",
+			Rule:    &warningRule{},
+			Status:  ExitCodeOK,
+		},
 		{
 			Name:    "checking errors are occurred",
 			Command: "./tflint",
@@ -477,3 +525,956 @@ func TestCLIRun__withArguments(t *testing.T) {
 		}
 	}
 }
+
+func TestCLIRun__fix(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		src := `// comment
+resource "null_resource" "foo" {
+  triggers = "${var.triggers}"
+}
+`
+		if err := os.WriteFile("main.tf", []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+		cli := NewCLI(outStream, errStream)
+
+		status := cli.Run([]string{"./tflint", "--fix", "--only", "terraform_comment_syntax", "--only", "terraform_deprecated_interpolation"})
+		if status != ExitCodeOK {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeOK, status, outStream.String(), errStream.String())
+		}
+		if outStream.String() != "" {
+			t.Fatalf("Expected empty in stdout, but get `%s`", outStream.String())
+		}
+
+		got, err := os.ReadFile("main.tf")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `# comment
+resource "null_resource" "foo" {
+  triggers = var.triggers
+}
+`
+		if string(got) != expected {
+			t.Fatalf("Expected fixed file is `%s`, but get `%s`", expected, string(got))
+		}
+	})
+}
+
+func TestCLIRun__recursive(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		for _, name := range []string{"a", "b"} {
+			if err := os.Mkdir(name, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(name, "main.tf"), []byte("// comment\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+		cli := NewCLI(outStream, errStream)
+
+		status := cli.Run([]string{"./tflint", "--recursive", "--only", "terraform_comment_syntax", "--format", "compact"})
+		if status != ExitCodeIssuesFound {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeIssuesFound, status, outStream.String(), errStream.String())
+		}
+		for _, filename := range []string{filepath.Join("a", "main.tf"), filepath.Join("b", "main.tf")} {
+			if !strings.Contains(outStream.String(), filename) {
+				t.Fatalf("Expected to contain an issue in `%s`, but get `%s`", filename, outStream.String())
+			}
+		}
+	})
+}
+
+func Test_findRootModuleDirs(t *testing.T) {
+	dir := t.TempDir()
+
+	files := []string{
+		"a/main.tf",
+		"a/variables.tf",
+		"b/c/main.tf.json",
+		"d/README.md",
+		".terraform/modules/x/main.tf",
+		"a/.terraform/modules/y/main.tf",
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := findRootModuleDirs([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{filepath.Join(dir, "a"), filepath.Join(dir, "b", "c")}
+	if !cmp.Equal(expected, got) {
+		t.Fatalf("Test failed. Diff: %s", cmp.Diff(expected, got))
+	}
+
+	_, err = findRootModuleDirs([]string{filepath.Join(dir, "a", "main.tf")})
+	if err == nil {
+		t.Fatal("Expected error is not occurred")
+	}
+	expectedErr := fmt.Sprintf("Failed to load `%s`: Only directories are allowed in recursive mode", filepath.Join(dir, "a", "main.tf"))
+	if err.Error() != expectedErr {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expectedErr, err.Error())
+	}
+}
+
+func TestCLIRun__baseline(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		run := func(src string, args ...string) (int, string, string) {
+			if err := os.WriteFile("main.tf", []byte(src), 0644); err != nil {
+				t.Fatal(err)
+			}
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli := NewCLI(outStream, errStream)
+			status := cli.Run(append([]string{"./tflint", "--only", "terraform_comment_syntax", "--format", "compact"}, args...))
+			return status, outStream.String(), errStream.String()
+		}
+
+		status, stdout, stderr := run("// foo\n", "--write-baseline", "baseline.json")
+		if status != ExitCodeOK {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeOK, status, stdout, stderr)
+		}
+		if stdout != "1 issue(s) recorded to baseline.json\n" {
+			t.Fatalf("Unexpected stdout: %s", stdout)
+		}
+
+		// The recorded issue is not reported even if it is moved
+		status, stdout, stderr = run("# bar\n\n// foo\n", "--baseline", "baseline.json")
+		if status != ExitCodeOK {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeOK, status, stdout, stderr)
+		}
+
+		// New issues are reported
+		status, stdout, stderr = run("// foo\n// bar\n", "--baseline", "baseline.json")
+		if status != ExitCodeIssuesFound {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeIssuesFound, status, stdout, stderr)
+		}
+		if !strings.Contains(stdout, "main.tf:2:1") || strings.Contains(stdout, "main.tf:1:1") {
+			t.Fatalf("Expected only the new issue is reported, but get `%s`", stdout)
+		}
+
+		// Entries that no longer match are listed
+		status, stdout, stderr = run("# foo\n", "--baseline", "baseline.json")
+		if status != ExitCodeOK {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeOK, status, stdout, stderr)
+		}
+		if !strings.Contains(stderr, "1 baseline entries no longer match any issues") || !strings.Contains(stderr, "terraform_comment_syntax: main.tf") {
+			t.Fatalf("Expected stale entries are listed, but get `%s`", stderr)
+		}
+	})
+}
+
+func TestCLIRun__reportUnusedAnnotations(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		src := `# tflint-ignore: terraform_comment_syntax
+// used
+# tflint-ignore: terraform_comment_syntax
+# unused
+# tflint-ignore: unknown_rule
+`
+		if err := os.WriteFile("main.tf", []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+		cli := NewCLI(outStream, errStream)
+
+		status := cli.Run([]string{"./tflint", "--only", "terraform_comment_syntax", "--report-unused-annotations", "--format", "compact"})
+		if status != ExitCodeIssuesFound {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeIssuesFound, status, outStream.String(), errStream.String())
+		}
+
+		expected := "main.tf:3:1: Warning - The annotation for `terraform_comment_syntax` did not suppress any issues (tflint_unused_annotation)\n" +
+			"main.tf:5:1: Warning - `unknown_rule` rule in the annotation does not exist in any enabled ruleset (tflint_unused_annotation)\n"
+		if !strings.Contains(outStream.String(), expected) {
+			t.Fatalf("Expected to contain `%s` in stdout, but get `%s`", expected, outStream.String())
+		}
+		if strings.Contains(outStream.String(), "main.tf:1:1") {
+			t.Fatalf("Expected the used annotation is not reported, but get `%s`", outStream.String())
+		}
+	})
+}
+
+func TestCLIRun__outputs(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		if err := os.WriteFile("main.tf", []byte("// foo\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+		cli := NewCLI(outStream, errStream)
+
+		status := cli.Run([]string{"./tflint", "--only", "terraform_comment_syntax", "--format", "compact", "--output", "json:result.json", "--output", "junit:report.xml"})
+		if status != ExitCodeIssuesFound {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeIssuesFound, status, outStream.String(), errStream.String())
+		}
+		if !strings.Contains(outStream.String(), "main.tf:1:1: Warning") {
+			t.Fatalf("Expected the issue is printed to stdout, but get `%s`", outStream.String())
+		}
+
+		jsonOut, err := os.ReadFile("result.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(jsonOut), `"name":"terraform_comment_syntax"`) {
+			t.Fatalf("Expected the issue is written to result.json, but get `%s`", jsonOut)
+		}
+		junitOut, err := os.ReadFile("report.xml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(junitOut), "<testsuites>") {
+			t.Fatalf("Expected the issue is written to report.xml, but get `%s`", junitOut)
+		}
+
+		// Application errors must not corrupt report files
+		if err := os.WriteFile("main.tf", []byte("resource \"foo\" {\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		outStream, errStream = new(bytes.Buffer), new(bytes.Buffer)
+		cli = NewCLI(outStream, errStream)
+
+		status = cli.Run([]string{"./tflint", "--output", "junit:report.xml"})
+		if status != ExitCodeError {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeError, status, outStream.String(), errStream.String())
+		}
+		if !strings.Contains(errStream.String(), "Failed to load configurations") {
+			t.Fatalf("Expected the error is printed to stderr, but get `%s`", errStream.String())
+		}
+		junitOut, err = os.ReadFile("report.xml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var report struct {
+			XMLName xml.Name `xml:"testsuites"`
+		}
+		if err := xml.Unmarshal(junitOut, &report); err != nil {
+			t.Fatalf("Expected report.xml is valid XML, but get `%s`: %s", junitOut, err)
+		}
+		if strings.Contains(string(junitOut), "Failed to load configurations") {
+			t.Fatalf("Expected the error is not written to report.xml, but get `%s`", junitOut)
+		}
+
+		outStream, errStream = new(bytes.Buffer), new(bytes.Buffer)
+		cli = NewCLI(outStream, errStream)
+
+		status = cli.Run([]string{"./tflint", "--output", "unknown:result.txt"})
+		if status != ExitCodeError {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeError, status, outStream.String(), errStream.String())
+		}
+		if !strings.Contains(errStream.String(), "unknown is invalid format") {
+			t.Fatalf("Expected the invalid format error, but get `%s`", errStream.String())
+		}
+	})
+}
+
+func TestCLIRun__template(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		if err := os.WriteFile("main.tf", []byte("// foo\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile("report.tmpl", []byte(`{{ range .Issues }}{{ .Range.Filename }}:{{ .Range.Start.Line }} {{ .Rule.Name }}{{ end }}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		cases := []struct {
+			Name    string
+			Command string
+			Status  int
+			Stdout  string
+			Stderr  string
+		}{
+			{
+				Name:    "template format",
+				Command: "./tflint --only terraform_comment_syntax --format template --template-file report.tmpl",
+				Status:  ExitCodeIssuesFound,
+				Stdout:  "main.tf:1 terraform_comment_syntax",
+			},
+			{
+				Name:    "template output",
+				Command: "./tflint --only terraform_comment_syntax --format compact --template-file report.tmpl --output template:report.txt",
+				Status:  ExitCodeIssuesFound,
+				Stdout:  "main.tf:1:1: Warning",
+			},
+			{
+				Name:    "without template file",
+				Command: "./tflint --format template",
+				Status:  ExitCodeError,
+				Stderr:  "the template format requires --template-file",
+			},
+			{
+				Name:    "template file not found",
+				Command: "./tflint --format template --template-file not_found.tmpl",
+				Status:  ExitCodeError,
+				Stderr:  "Failed to load template file; `not_found.tmpl` is not found",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.Name, func(t *testing.T) {
+				outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+				cli := NewCLI(outStream, errStream)
+				status := cli.Run(strings.Split(tc.Command, " "))
+
+				if status != tc.Status {
+					t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", tc.Status, status, outStream.String(), errStream.String())
+				}
+				if !strings.Contains(outStream.String(), tc.Stdout) {
+					t.Fatalf("Expected to contain `%s` in stdout, but get `%s`", tc.Stdout, outStream.String())
+				}
+				if !strings.Contains(errStream.String(), tc.Stderr) {
+					t.Fatalf("Expected to contain `%s` in stderr, but get `%s`", tc.Stderr, errStream.String())
+				}
+			})
+		}
+
+		out, err := os.ReadFile("report.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != "main.tf:1 terraform_comment_syntax" {
+			t.Fatalf("Unexpected template output: %s", out)
+		}
+	})
+}
+
+func TestCLIRun__diff(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		if err := os.WriteFile("main.tf", []byte("// foo\n// bar\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		diff := `--- a/main.tf
++++ b/main.tf
+@@ -1 +1,2 @@
+ // foo
++// bar
+`
+		if err := os.WriteFile("changes.diff", []byte(diff), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+		cli := NewCLI(outStream, errStream)
+
+		status := cli.Run([]string{"./tflint", "--only", "terraform_comment_syntax", "--format", "compact", "--diff-file", "changes.diff"})
+		if status != ExitCodeIssuesFound {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeIssuesFound, status, outStream.String(), errStream.String())
+		}
+		if !strings.Contains(outStream.String(), "main.tf:2:1") || strings.Contains(outStream.String(), "main.tf:1:1") {
+			t.Fatalf("Expected only the issue on the changed line is reported, but get `%s`", outStream.String())
+		}
+
+		outStream, errStream = new(bytes.Buffer), new(bytes.Buffer)
+		cli = NewCLI(outStream, errStream)
+
+		status = cli.Run([]string{"./tflint", "--diff-file", "not_found.diff"})
+		if status != ExitCodeError {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeError, status, outStream.String(), errStream.String())
+		}
+		if !strings.Contains(errStream.String(), "Failed to load diff; `not_found.diff` is not found") {
+			t.Fatalf("Unexpected stderr: %s", errStream.String())
+		}
+	})
+}
+
+func TestCLIRun__diffBase(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	withinTempDir(t, func(dir string) {
+		git := func(args ...string) {
+			cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("Failed to run git %s: %s", strings.Join(args, " "), out)
+			}
+		}
+		git("init", "-q")
+		if err := os.WriteFile("main.tf", []byte("// foo\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "main.tf")
+		git("commit", "-q", "-m", "initial")
+		if err := os.WriteFile("main.tf", []byte("// foo\n// bar\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+		cli := NewCLI(outStream, errStream)
+
+		status := cli.Run([]string{"./tflint", "--only", "terraform_comment_syntax", "--format", "compact", "--diff-base", "HEAD"})
+		if status != ExitCodeIssuesFound {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeIssuesFound, status, outStream.String(), errStream.String())
+		}
+		if !strings.Contains(outStream.String(), "main.tf:2:1") || strings.Contains(outStream.String(), "main.tf:1:1") {
+			t.Fatalf("Expected only the issue on the changed line is reported, but get `%s`", outStream.String())
+		}
+	})
+}
+
+func TestCLIRun__jobs(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		for _, name := range []string{"a.tf", "b.tf", "c.tf"} {
+			if err := os.WriteFile(name, []byte("// foo\n// bar\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var expected string
+		for _, jobs := range []string{"1", "4"} {
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli := NewCLI(outStream, errStream)
+
+			status := cli.Run([]string{"./tflint", "--only", "terraform_comment_syntax", "--format", "compact", "--jobs", jobs})
+			if status != ExitCodeIssuesFound {
+				t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeIssuesFound, status, outStream.String(), errStream.String())
+			}
+			if expected == "" {
+				expected = outStream.String()
+			} else if outStream.String() != expected {
+				t.Fatalf("Expected the same output regardless of jobs:\n%s", cmp.Diff(expected, outStream.String()))
+			}
+		}
+
+		for _, jobs := range []string{"0", "-1"} {
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli := NewCLI(outStream, errStream)
+
+			status := cli.Run([]string{"./tflint", "--jobs", jobs})
+			if status != ExitCodeError {
+				t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeError, status, outStream.String(), errStream.String())
+			}
+			if !strings.Contains(errStream.String(), "--jobs must be a positive number") {
+				t.Fatalf("Unexpected stderr: %s", errStream.String())
+			}
+		}
+	})
+}
+
+func TestCLIRun__hierarchicalConfig(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		files := map[string]string{
+			".tflint.hcl": `
+config {
+  disabled_by_default = true
+}
+
+rule "terraform_comment_syntax" {
+  enabled = true
+}`,
+			"a/main.tf": "// foo\n",
+			"b/main.tf": "// foo\n",
+			"b/.tflint.hcl": `
+rule "terraform_comment_syntax" {
+  enabled = false
+}`,
+		}
+		if err := os.Mkdir(".git", 0755); err != nil {
+			t.Fatal(err)
+		}
+		for name, src := range files {
+			if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(name, []byte(src), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		tests := []struct {
+			name   string
+			args   []string
+			status int
+			want   []string
+		}{
+			{
+				name:   "config in the current directory",
+				args:   []string{"./tflint", "--recursive", "--format", "compact"},
+				status: ExitCodeIssuesFound,
+				want:   []string{filepath.Join("a", "main.tf"), filepath.Join("b", "main.tf")},
+			},
+			{
+				name:   "hierarchical config",
+				args:   []string{"./tflint", "--recursive", "--format", "compact", "--hierarchical-config"},
+				status: ExitCodeIssuesFound,
+				want:   []string{filepath.Join("a", "main.tf")},
+			},
+			{
+				name:   "nearer config in the directory",
+				args:   []string{"./tflint", "--format", "compact", "--hierarchical-config", "b"},
+				status: ExitCodeOK,
+				want:   []string{},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+				cli := NewCLI(outStream, errStream)
+
+				status := cli.Run(test.args)
+				if status != test.status {
+					t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", test.status, status, outStream.String(), errStream.String())
+				}
+				got := []string{}
+				for _, line := range strings.Split(strings.TrimSpace(outStream.String()), "\n") {
+					if strings.Contains(line, "terraform_comment_syntax") {
+						got = append(got, strings.SplitN(line, ":", 2)[0])
+					}
+				}
+				if diff := cmp.Diff(test.want, got); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		}
+	})
+}
+
+func TestCLIRun__exclude(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		for _, name := range []string{"main.tf", "foo.generated.tf"} {
+			if err := os.WriteFile(name, []byte("// foo\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, args := range [][]string{{"--exclude", "*.generated.tf"}, {"--exclude", "*.generated.tf", "--hard-exclude"}} {
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli := NewCLI(outStream, errStream)
+
+			status := cli.Run(append([]string{"./tflint", "--only", "terraform_comment_syntax", "--format", "compact"}, args...))
+			if status != ExitCodeIssuesFound {
+				t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeIssuesFound, status, outStream.String(), errStream.String())
+			}
+			if !strings.Contains(outStream.String(), "main.tf") || strings.Contains(outStream.String(), "foo.generated.tf") {
+				t.Fatalf("Expected only issues in main.tf with %v, but got %s", args, outStream.String())
+			}
+		}
+	})
+}
+
+func TestCLIRun__excludeInvalidPattern(t *testing.T) {
+	outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+	cli := NewCLI(outStream, errStream)
+
+	status := cli.Run([]string{"./tflint", "--exclude", "[*.tf"})
+	if status != ExitCodeError {
+		t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeError, status, outStream.String(), errStream.String())
+	}
+	expected := "Failed to parse CLI options; `[*.tf` is invalid pattern"
+	if !strings.Contains(errStream.String(), expected) {
+		t.Fatalf("Expected `%s` in the error, but got %s", expected, errStream.String())
+	}
+}
+
+func Test_runConcurrently(t *testing.T) {
+	funcs := []func() error{
+		func() error { return nil },
+		func() error { return errors.New("first") },
+		func() error { return errors.New("second") },
+	}
+
+	for _, jobs := range []int{1, 2, 10} {
+		err := runConcurrently(funcs, jobs)
+		if err == nil || err.Error() != "first" {
+			t.Fatalf("Expected the first error with %d jobs, but got %v", jobs, err)
+		}
+	}
+
+	if err := runConcurrently([]func() error{}, 1); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestCLIRun__cache(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		if err := os.WriteFile("main.tf", []byte("// foo\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		run := func() string {
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli := NewCLI(outStream, errStream)
+
+			status := cli.Run([]string{"./tflint", "--only", "terraform_comment_syntax", "--format", "compact", "--cache"})
+			if status != ExitCodeIssuesFound {
+				t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeIssuesFound, status, outStream.String(), errStream.String())
+			}
+			return outStream.String()
+		}
+
+		first := run()
+		entries, err := os.ReadDir(filepath.Join(".terraform", "tflint-cache"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 cache entry, but got %d", len(entries))
+		}
+
+		if second := run(); second != first {
+			t.Fatalf("Expected the same output with the cache:\n%s", cmp.Diff(first, second))
+		}
+		if !strings.Contains(first, "main.tf:1:1: Warning") {
+			t.Fatalf("Unexpected output: %s", first)
+		}
+	})
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestCLIRun__watch(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		if err := os.WriteFile("main.tf", []byte("// foo\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		outStream, errStream := &syncBuffer{}, &syncBuffer{}
+		cli := NewCLI(outStream, errStream)
+		cli.formatter = &formatter.Formatter{Stdout: outStream, Stderr: errStream, Format: "default", NoColor: true}
+		opts := Options{Config: ".tflint.hcl", Only: []string{"terraform_comment_syntax"}, Jobs: 1}
+
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			cli.watchLoop(opts, ".", []string{}, &plugin.Plugin{}, 10*time.Millisecond, stop)
+			close(done)
+		}()
+
+		waitFor := func(stream *syncBuffer, text string) {
+			deadline := time.Now().Add(10 * time.Second)
+			for !strings.Contains(stream.String(), text) {
+				if time.Now().After(deadline) {
+					t.Fatalf("Expected `%s` in the output, but got `%s`", text, stream.String())
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+
+		waitFor(outStream, "1 issue(s) found")
+		if err := os.WriteFile("main.tf", []byte("// foo\n// bar\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		waitFor(outStream, "1 new issue(s), 0 fixed issue(s) since the last run")
+		if !strings.Contains(outStream.String(), "main.tf changed. Inspecting again...") {
+			t.Fatalf("Expected the changed file is printed, but got `%s`", outStream.String())
+		}
+
+		close(stop)
+		<-done
+
+		// Each inspection respects the format and the other options, and progress messages are printed to stderr
+		outStream, errStream = &syncBuffer{}, &syncBuffer{}
+		cli = NewCLI(outStream, errStream)
+		cli.formatter = &formatter.Formatter{Stdout: outStream, Stderr: errStream, Format: "default", NoColor: true}
+		opts = Options{Config: ".tflint.hcl", Only: []string{"terraform_comment_syntax"}, Format: "json", MinimumSeverity: "error", Jobs: 1}
+
+		stop = make(chan struct{})
+		done = make(chan struct{})
+		go func() {
+			cli.watchLoop(opts, ".", []string{}, &plugin.Plugin{}, 10*time.Millisecond, stop)
+			close(done)
+		}()
+
+		waitFor(errStream, "Watching for changes...")
+		close(stop)
+		<-done
+
+		if outStream.String() != `{"issues":[],"errors":[]}` {
+			t.Fatalf("Expected the JSON output without warnings, but got `%s`", outStream.String())
+		}
+
+		errOut, errErr := new(bytes.Buffer), new(bytes.Buffer)
+		status := NewCLI(errOut, errErr).Run([]string{"./tflint", "--watch", "--fix"})
+		if status != ExitCodeError {
+			t.Fatalf("Expected status is `%d`, but get `%d`", ExitCodeError, status)
+		}
+		if !strings.Contains(errErr.String(), "--watch cannot be used with --fix") {
+			t.Fatalf("Unexpected stderr: %s", errErr.String())
+		}
+	})
+}
+
+func TestCLIRun__listRules(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		config := `
+rule "terraform_comment_syntax" {
+  enabled  = true
+  severity = "error"
+}
+
+rule "terraform_typed_variables" {
+  enabled = false
+}
+`
+		if err := os.WriteFile(".tflint.hcl", []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+		cli := NewCLI(outStream, errStream)
+
+		status := cli.Run([]string{"./tflint", "--list-rules", "--format", "json", "--disable-rule", "terraform_unused_declarations"})
+		if status != ExitCodeOK {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeOK, status, outStream.String(), errStream.String())
+		}
+
+		var got ruleStates
+		if err := json.Unmarshal(outStream.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		states := map[string]ruleState{}
+		for _, state := range got.Rules {
+			states[state.Name] = state
+		}
+		if len(states) != len(rules.DefaultRules) {
+			t.Fatalf("Expected %d rules, but got %d", len(rules.DefaultRules), len(states))
+		}
+
+		enabled, disabled := true, false
+		expected := map[string]ruleState{
+			"terraform_comment_syntax":           {Name: "terraform_comment_syntax", RuleSet: "core", Version: tflint.Version, DefaultEnabled: &disabled, Enabled: &enabled, Reason: "enabled in the config file", Severity: "error"},
+			"terraform_typed_variables":          {Name: "terraform_typed_variables", RuleSet: "core", Version: tflint.Version, DefaultEnabled: &disabled, Enabled: &disabled, Reason: "disabled in the config file", Severity: "warning"},
+			"terraform_unused_declarations":      {Name: "terraform_unused_declarations", RuleSet: "core", Version: tflint.Version, DefaultEnabled: &disabled, Enabled: &disabled, Reason: "disabled via --disable-rule", Severity: "warning"},
+			"terraform_deprecated_index":         {Name: "terraform_deprecated_index", RuleSet: "core", Version: tflint.Version, DefaultEnabled: &disabled, Enabled: &disabled, Reason: "disabled by default", Severity: "warning"},
+			"terraform_module_version":           {Name: "terraform_module_version", RuleSet: "core", Version: tflint.Version, DefaultEnabled: &enabled, Enabled: &enabled, Reason: "enabled by default", Severity: "warning"},
+			"terraform_deprecated_interpolation": {Name: "terraform_deprecated_interpolation", RuleSet: "core", Version: tflint.Version, DefaultEnabled: &enabled, Enabled: &enabled, Reason: "enabled by default", Severity: "warning"},
+		}
+		for name, want := range expected {
+			if diff := cmp.Diff(want, states[name]); diff != "" {
+				t.Fatalf("Unexpected state of `%s`: %s", name, diff)
+			}
+		}
+	})
+}
+
+func TestCLIRun__listRules_hierarchicalConfig(t *testing.T) {
+	withinTempDir(t, func(dir string) {
+		if err := os.Mkdir(filepath.Join(dir, ".git"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ".tflint.hcl"), []byte(`
+rule "terraform_comment_syntax" {
+  enabled  = true
+  severity = "error"
+}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		child := filepath.Join(dir, "production")
+		if err := os.Mkdir(child, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(child, ".tflint.hcl"), []byte(`
+rule "terraform_comment_syntax" {
+  enabled = true
+}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(child); err != nil {
+			t.Fatal(err)
+		}
+
+		outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+		cli := NewCLI(outStream, errStream)
+
+		status := cli.Run([]string{"./tflint", "--list-rules", "--format", "json", "--hierarchical-config"})
+		if status != ExitCodeOK {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeOK, status, outStream.String(), errStream.String())
+		}
+
+		var got ruleStates
+		if err := json.Unmarshal(outStream.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+
+		enabled, disabled := true, false
+		want := ruleState{Name: "terraform_comment_syntax", RuleSet: "core", Version: tflint.Version, DefaultEnabled: &disabled, Enabled: &enabled, Reason: "enabled in the config file", Severity: "error"}
+		for _, state := range got.Rules {
+			if state.Name != want.Name {
+				continue
+			}
+			if diff := cmp.Diff(want, state); diff != "" {
+				t.Fatalf("Unexpected state of `%s`: %s", want.Name, diff)
+			}
+			return
+		}
+		t.Fatalf("`%s` is not listed", want.Name)
+	})
+}
+
+func Test_resolveRuleState(t *testing.T) {
+	enabled, disabled := true, false
+
+	cases := []struct {
+		Name           string
+		DefaultEnabled *bool
+		Config         *tflint.Config
+		Opts           Options
+		Expected       *bool
+		Reason         string
+	}{
+		{
+			Name:           "default",
+			DefaultEnabled: &enabled,
+			Config:         tflint.EmptyConfig(),
+			Expected:       &enabled,
+			Reason:         "enabled by default",
+		},
+		{
+			Name:     "plugin default",
+			Config:   tflint.EmptyConfig(),
+			Expected: nil,
+			Reason:   "default of the plugin",
+		},
+		{
+			Name:           "disabled_by_default",
+			DefaultEnabled: &enabled,
+			Config:         &tflint.Config{DisabledByDefault: true},
+			Expected:       &disabled,
+			Reason:         "disabled via disabled_by_default",
+		},
+		{
+			Name:           "enable-rule",
+			DefaultEnabled: &disabled,
+			Config: &tflint.Config{Rules: map[string]*tflint.RuleConfig{
+				"test_rule": {Name: "test_rule", Enabled: false},
+			}},
+			Opts:     Options{EnableRules: []string{"test_rule"}},
+			Expected: &enabled,
+			Reason:   "enabled via --enable-rule",
+		},
+		{
+			Name:           "disable-rule takes precedence over enable-rule",
+			DefaultEnabled: &enabled,
+			Config:         tflint.EmptyConfig(),
+			Opts:           Options{EnableRules: []string{"test_rule"}, DisableRules: []string{"test_rule"}},
+			Expected:       &disabled,
+			Reason:         "disabled via --disable-rule",
+		},
+		{
+			Name:           "only",
+			DefaultEnabled: &enabled,
+			Config:         tflint.EmptyConfig(),
+			Opts:           Options{Only: []string{"other_rule"}, EnableRules: []string{"test_rule"}},
+			Expected:       &disabled,
+			Reason:         "disabled via --only",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, reason := resolveRuleState("test_rule", tc.DefaultEnabled, tc.Config, tc.Opts)
+			if diff := cmp.Diff(tc.Expected, got); diff != "" {
+				t.Fatal(diff)
+			}
+			if reason != tc.Reason {
+				t.Fatalf("Expected reason is `%s`, but got `%s`", tc.Reason, reason)
+			}
+		})
+	}
+}
+
+func TestCLIRun__explain(t *testing.T) {
+	outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+	cli := NewCLI(outStream, errStream)
+
+	status := cli.Run([]string{"./tflint", "--explain", "terraform_naming_convention"})
+	if status != ExitCodeOK {
+		t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeOK, status, outStream.String(), errStream.String())
+	}
+	for _, want := range []string{"Ruleset: core", "Default severity: notice", "Enabled by default: false", "# terraform_naming_convention", "## Configuration", "## Examples"} {
+		if !strings.Contains(outStream.String(), want) {
+			t.Fatalf("Expected `%s` in the output, but got `%s`", want, outStream.String())
+		}
+	}
+
+	withinTempDir(t, func(string) {
+		outStream, errStream = new(bytes.Buffer), new(bytes.Buffer)
+		cli = NewCLI(outStream, errStream)
+
+		status = cli.Run([]string{"./tflint", "--explain", "not_found"})
+		if status != ExitCodeError {
+			t.Fatalf("Expected status is `%d`, but get `%d`: stdout=%s, stderr=%s", ExitCodeError, status, outStream.String(), errStream.String())
+		}
+		if !strings.Contains(errStream.String(), "Rule not found: not_found") {
+			t.Fatalf("Unexpected stderr: %s", errStream.String())
+		}
+	})
+}
+
+func Test_pluginRuleDoc(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Config *tflint.Config
+		Want   []string
+	}{
+		{
+			Name: "auto installed plugin",
+			Config: &tflint.Config{
+				Plugins: map[string]*tflint.PluginConfig{
+					"aws": {
+						Name:        "aws",
+						Enabled:     true,
+						Version:     "0.4.0",
+						Source:      "github.com/terraform-linters/tflint-ruleset-aws",
+						SourceOwner: "terraform-linters",
+						SourceRepo:  "tflint-ruleset-aws",
+					},
+				},
+			},
+			Want: []string{
+				"Link: https://github.com/terraform-linters/tflint-ruleset-aws/blob/v0.4.0/docs/rules/aws_instance_invalid_type.md",
+				"only the link to the plugin repository is shown",
+			},
+		},
+		{
+			Name: "manually installed plugin",
+			Config: &tflint.Config{
+				Plugins: map[string]*tflint.PluginConfig{
+					"aws": {Name: "aws", Enabled: true},
+				},
+			},
+			Want: []string{
+				"No documentation is available offline",
+				"the repository of the `aws` plugin is unknown because it is installed manually",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := pluginRuleDoc(test.Config, "aws", "aws_instance_invalid_type")
+			for _, want := range test.Want {
+				if !strings.Contains(got, want) {
+					t.Fatalf("Expected `%s` in the output, but got `%s`", want, got)
+				}
+			}
+		})
+	}
+}
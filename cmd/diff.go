@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// loadChangedLines returns the lines changed in the diff specified by `--diff-file` or `--diff-base`.
+func loadChangedLines(opts Options) (tflint.ChangedLines, error) {
+	var diff []byte
+	if opts.DiffFile != "" {
+		var err error
+		diff, err = os.ReadFile(opts.DiffFile)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("`%s` is not found", opts.DiffFile)
+			}
+			return nil, err
+		}
+	} else {
+		// Paths are relative to the current directory to match file names of issues
+		cmd := exec.Command("git", "diff", "--relative", "--no-color", "--no-ext-diff", "--unified=0", opts.DiffBase, "--")
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("Failed to run `git diff %s`; %w: %s", opts.DiffBase, err, strings.TrimSpace(stderr.String()))
+		}
+		diff = stdout.Bytes()
+	}
+
+	return tflint.ParseUnifiedDiff(diff)
+}
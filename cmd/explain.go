@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/terraform-linters/tflint/docs"
+	"github.com/terraform-linters/tflint/plugin"
+	"github.com/terraform-linters/tflint/rules"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// explain prints the documentation of the rule.
+// Documentation of core rules is embedded in the binary. For plugin rules, it prints a link to the plugin repository.
+// See pluginRuleDoc.
+func (cli *CLI) explain(opts Options) int {
+	name := opts.Explain
+
+	for _, rule := range rules.DefaultRules {
+		if rule.Name() != name {
+			continue
+		}
+
+		fmt.Fprintf(cli.outStream, "%s\n\n", name)
+		fmt.Fprintf(cli.outStream, "Ruleset: core (%s)\n", tflint.Version)
+		fmt.Fprintf(cli.outStream, "Default severity: %s\n", strings.ToLower(rule.Severity().String()))
+		fmt.Fprintf(cli.outStream, "Enabled by default: %t\n", rule.Enabled())
+		if rule.Link() != "" {
+			fmt.Fprintf(cli.outStream, "Link: %s\n", rule.Link())
+		}
+
+		doc, exists := docs.RuleDoc(name)
+		if !exists {
+			fmt.Fprintln(cli.outStream, "\nNo documentation is available.")
+			return ExitCodeOK
+		}
+		fmt.Fprintf(cli.outStream, "\n%s", doc)
+		return ExitCodeOK
+	}
+
+	// Look up plugins to find the rule
+	cfg, err := loadConfig(opts)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+	rulesetPlugin, err := plugin.Discovery(cfg)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to initialize plugins; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	defer rulesetPlugin.Clean()
+
+	pluginNames := []string{}
+	for pluginName := range rulesetPlugin.RuleSets {
+		pluginNames = append(pluginNames, pluginName)
+	}
+	sort.Strings(pluginNames)
+
+	for _, pluginName := range pluginNames {
+		ruleset := rulesetPlugin.RuleSets[pluginName]
+		ruleNames, err := ruleset.RuleNames()
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to fetch rule names from `%s` plugin; %w", pluginName, err), map[string][]byte{})
+			return ExitCodeError
+		}
+		found := false
+		for _, ruleName := range ruleNames {
+			if ruleName == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+
+		version, err := ruleset.RuleSetVersion()
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to get `%s` plugin version; %w", pluginName, err), map[string][]byte{})
+			return ExitCodeError
+		}
+		fmt.Fprintf(cli.outStream, "%s\n\n", name)
+		fmt.Fprintf(cli.outStream, "Ruleset: %s (%s)\n", pluginName, version)
+
+		fmt.Fprint(cli.outStream, pluginRuleDoc(cfg, pluginName, name))
+		return ExitCodeOK
+	}
+
+	cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Rule not found: %s", name), map[string][]byte{})
+	return ExitCodeError
+}
+
+// pluginRuleDoc returns the documentation of the plugin rule.
+// Plugins do not provide documentation over gRPC, so only a link to the documentation in the plugin repository
+// is available. For manually installed plugins, the repository is unknown, so no documentation is available.
+func pluginRuleDoc(cfg *tflint.Config, pluginName string, rule string) string {
+	if pluginCfg, exists := cfg.Plugins[pluginName]; exists {
+		if link := plugin.NewInstallConfig(cfg, pluginCfg).RuleDocLink(rule); link != "" {
+			return fmt.Sprintf("Link: %s\n\nPlugins do not provide documentation to TFLint, so only the link to the plugin repository is shown.\n", link)
+		}
+	}
+	return fmt.Sprintf("\nNo documentation is available offline. Plugins do not provide documentation to TFLint, and the repository of the `%s` plugin is unknown because it is installed manually. See the documentation of the plugin.\n", pluginName)
+}
@@ -2,6 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/spf13/afero"
@@ -11,114 +15,367 @@ import (
 	"github.com/terraform-linters/tflint/tflint"
 )
 
-func (cli *CLI) inspect(opts Options, dir string, filterFiles []string) int {
+func (cli *CLI) inspect(opts Options, dirs []string, filterFiles []string) int {
 	// Setup config
-	cfg, err := tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, opts.Config)
+	cfg, dirConfigs, err := loadDirConfigs(opts, dirs)
 	if err != nil {
-		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to load TFLint config; %w", err), map[string][]byte{})
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
 		return ExitCodeError
 	}
-	if len(opts.Only) > 0 {
-		for _, rule := range cfg.Rules {
-			rule.Enabled = false
-		}
+
+	// Lookup plugins
+	rulesetPlugin, err := plugin.Discovery(withPluginsOf(cfg, dirConfigs))
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to initialize plugins; %w", err), map[string][]byte{})
+		return ExitCodeError
 	}
-	cfg.Merge(opts.toConfig())
+	defer rulesetPlugin.Clean()
+
+	status, _, _ := cli.inspectWithPlugin(opts, cfg, dirConfigs, dirs, filterFiles, rulesetPlugin)
+	return status
+}
+
+// inspectWithPlugin inspects the directories with the loaded configs and the running plugins, and prints the results.
+// It returns the exit status, and the printed issues and their sources.
+// It is called once for a normal run, and every time files are changed with --watch.
+func (cli *CLI) inspectWithPlugin(opts Options, cfg *tflint.Config, dirConfigs map[string]*tflint.Config, dirs []string, filterFiles []string, rulesetPlugin *plugin.Plugin) (int, tflint.Issues, map[string][]byte) {
 	cli.formatter.Format = cfg.Format
+	if cli.formatter.Format == "template" && cli.formatter.Template == nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to load TFLint config; the template format requires --template-file"), map[string][]byte{})
+		return ExitCodeError, nil, nil
+	}
 
 	// Setup loader
+	// With --watch, the loader is kept alive, and its cache is invalidated to read the changed files
+	var err error
 	if !cli.testMode {
-		cli.loader, err = tflint.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, cfg)
+		if loader, ok := cli.loader.(*tflint.Loader); ok {
+			err = loader.Invalidate(cfg)
+		} else {
+			cli.loader, err = tflint.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, cfg)
+		}
 		if err != nil {
 			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to prepare loading; %w", err), map[string][]byte{})
-			return ExitCodeError
+			return ExitCodeError, nil, nil
 		}
 	}
 
-	// Setup runners
-	runners, appErr := cli.setupRunners(opts, cfg, dir)
-	if appErr != nil {
-		cli.formatter.Print(tflint.Issues{}, appErr, cli.loader.Sources())
-		return ExitCodeError
+	if !opts.HierarchicalConfig {
+		if err := applyPluginConfig(cfg, rulesetPlugin); err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, cli.loader.Sources())
+			return ExitCodeError, nil, nil
+		}
 	}
-	rootRunner := runners[len(runners)-1]
 
-	// Lookup plugins and validation
-	rulesetPlugin, err := plugin.Discovery(cfg)
-	if err != nil {
-		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to initialize plugins; %w", err), cli.loader.Sources())
-		return ExitCodeError
+	// Setup cache
+	var cache *tflint.Cache
+	if opts.Cache {
+		cache, err = newCache(rulesetPlugin)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to prepare cache; %w", err), cli.loader.Sources())
+			return ExitCodeError, nil, nil
+		}
 	}
-	defer rulesetPlugin.Clean()
 
-	rulesets := []tflint.RuleSet{&rules.RuleSet{}}
-	config := cfg.ToPluginConfig()
-	for name, ruleset := range rulesetPlugin.RuleSets {
-		if err := ruleset.ApplyGlobalConfig(config); err != nil {
-			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to apply global config to `%s` plugin; %w", name, err), cli.loader.Sources())
-			return ExitCodeError
+	// Load baseline
+	var baseline *tflint.Baseline
+	if opts.Baseline != "" && opts.WriteBaseline == "" {
+		baseline, err = tflint.LoadBaseline(afero.Afero{Fs: afero.NewOsFs()}, opts.Baseline)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to load baseline; %w", err), cli.loader.Sources())
+			return ExitCodeError, nil, nil
 		}
-		configSchema, err := ruleset.ConfigSchema()
+	}
+
+	// Load changed lines to report only issues on them
+	var changes tflint.ChangedLines
+	if opts.DiffBase != "" || opts.DiffFile != "" {
+		if opts.DiffBase != "" && opts.DiffFile != "" {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; --diff-base and --diff-file cannot be used together"), cli.loader.Sources())
+			return ExitCodeError, nil, nil
+		}
+		changes, err = loadChangedLines(opts)
 		if err != nil {
-			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to fetch config schema from `%s` plugin; %w", name, err), cli.loader.Sources())
-			return ExitCodeError
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to load diff; %w", err), cli.loader.Sources())
+			return ExitCodeError, nil, nil
 		}
-		content := &hclext.BodyContent{}
-		if plugin, exists := cfg.Plugins[name]; exists {
-			var diags hcl.Diagnostics
-			content, diags = plugin.Content(configSchema)
-			if diags.HasErrors() {
-				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse `%s` plugin config; %w", name, diags), cli.loader.Sources())
-				return ExitCodeError
+	}
+
+	// Run inspection for each root module.
+	// The loader and plugins are shared, and issues are accumulated to print them at once.
+	issues := tflint.Issues{}
+	suppressed := tflint.Issues{}
+	sources := map[string][]byte{}
+	for _, dir := range dirs {
+		dirCfg := dirConfigs[dir]
+		if opts.HierarchicalConfig {
+			// The loader reads module settings from the config, so a new loader is required for each directory
+			if !cli.testMode {
+				cli.loader, err = tflint.NewLoader(afero.Afero{Fs: afero.NewOsFs()}, dirCfg)
+				if err != nil {
+					cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to prepare loading; %w", err), sources)
+					return ExitCodeError, nil, nil
+				}
+			}
+			if err := applyPluginConfig(dirCfg, rulesetPlugin); err != nil {
+				cli.formatter.Print(tflint.Issues{}, err, cli.loader.Sources())
+				return ExitCodeError, nil, nil
+			}
+		}
+		if opts.Recursive {
+			if err := cli.loader.SwitchRoot(dir); err != nil {
+				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to prepare loading; %w", err), cli.loader.Sources())
+				return ExitCodeError, nil, nil
 			}
 		}
-		err = ruleset.ApplyConfig(content, cfg.Sources())
+
+		// Setup runners
+		runners, appErr := cli.setupRunners(opts, dirCfg, dir)
+		if appErr != nil {
+			cli.formatter.Print(tflint.Issues{}, appErr, cli.loader.Sources())
+			return ExitCodeError, nil, nil
+		}
+
+		dirIssues, dirSuppressed, err := cli.inspectRunners(dirCfg, runners, rulesetPlugin, filterFiles, opts.Jobs, cache)
 		if err != nil {
-			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to apply config to `%s` plugin; %w", name, err), cli.loader.Sources())
-			return ExitCodeError
+			cli.formatter.Print(tflint.Issues{}, err, cli.loader.Sources())
+			return ExitCodeError, nil, nil
 		}
 
-		rulesets = append(rulesets, ruleset)
+		// Apply fixes and inspect again
+		if opts.Fix {
+			dirIssues, dirSuppressed, err = cli.fix(opts, dirCfg, dir, filterFiles, dirIssues, dirSuppressed, rulesetPlugin, cache)
+			if err != nil {
+				cli.formatter.Print(tflint.Issues{}, err, cli.loader.Sources())
+				return ExitCodeError, nil, nil
+			}
+		}
+
+		issues = append(issues, dirIssues...)
+		suppressed = append(suppressed, dirSuppressed...)
+		for name, src := range cli.loader.Sources() {
+			sources[name] = src
+		}
 	}
-	if err := cfg.ValidateRules(rulesets...); err != nil {
-		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to check rule config; %w", err), cli.loader.Sources())
-		return ExitCodeError
+
+	// Record issues to the baseline instead of reporting them
+	if opts.WriteBaseline != "" {
+		if err := tflint.NewBaseline(issues, sources).Write(afero.Afero{Fs: afero.NewOsFs()}, opts.WriteBaseline); err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to write baseline; %w", err), sources)
+			return ExitCodeError, nil, nil
+		}
+		fmt.Fprintf(cli.outStream, "%d issue(s) recorded to %s\n", len(issues), opts.WriteBaseline)
+		return ExitCodeOK, issues, sources
 	}
 
-	// Run inspection
-	for _, rule := range rules.NewRules(cfg) {
-		for _, runner := range runners {
-			err := rule.Check(runner)
-			if err != nil {
-				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to check `%s` rule; %w", rule.Name(), err), cli.loader.Sources())
-				return ExitCodeError
+	// Suppress pre-existing issues recorded in the baseline
+	if baseline != nil {
+		var stale []*tflint.BaselineIssue
+		issues, stale = baseline.Filter(issues, sources)
+		if len(stale) > 0 {
+			fmt.Fprintf(cli.errStream, "%d baseline entries no longer match any issues. Run with `--write-baseline` to prune them:\n", len(stale))
+			for _, entry := range stale {
+				if entry.Address != "" {
+					fmt.Fprintf(cli.errStream, "  - %s: %s (%s)\n", entry.Rule, entry.Filename, entry.Address)
+				} else {
+					fmt.Fprintf(cli.errStream, "  - %s: %s\n", entry.Rule, entry.Filename)
+				}
 			}
 		}
 	}
 
-	for _, ruleset := range rulesetPlugin.RuleSets {
+	// Hide issues not on the changed lines
+	if changes != nil {
+		issues = issues.FilterByChangedLines(changes)
+		suppressed = suppressed.FilterByChangedLines(changes)
+	}
+
+	// Hide issues below the minimum severity
+	if opts.MinimumSeverity != "" {
+		severity, err := tflint.ParseSeverity(opts.MinimumSeverity)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; %w", err), sources)
+			return ExitCodeError, nil, nil
+		}
+		issues = issues.FilterBySeverity(severity)
+	}
+
+	// Print issues
+	cli.formatter.SuppressedIssues = suppressed
+	cli.formatter.Print(issues, nil, sources)
+
+	failures := issues
+	if cfg.MinimumFailureSeverity != "" {
+		severity, err := tflint.ParseSeverity(cfg.MinimumFailureSeverity)
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; %w", err), sources)
+			return ExitCodeError, nil, nil
+		}
+		failures = issues.FilterBySeverity(severity)
+	}
+
+	if len(failures) > 0 && !cfg.Force {
+		return ExitCodeIssuesFound, issues, sources
+	}
+
+	return ExitCodeOK, issues, sources
+}
+
+// inspectRunners checks all rules with the passed runners.
+// It returns the issues to be reported and the issues suppressed by annotations.
+func (cli *CLI) inspectRunners(cfg *tflint.Config, runners []*tflint.Runner, rulesetPlugin *plugin.Plugin, filterFiles []string, jobs int, cache *tflint.Cache) (tflint.Issues, tflint.Issues, error) {
+	rootRunner := runners[len(runners)-1]
+	sources := cli.loader.Sources()
+
+	// Reuse cached results of unchanged modules, and check rules only with the other runners
+	targets := runners
+	if cache != nil {
+		targets = []*tflint.Runner{}
 		for _, runner := range runners {
-			err = ruleset.Check(plugin.NewGRPCServer(runner, rootRunner, cli.loader.Sources()))
+			if !cache.Load(runner) {
+				targets = append(targets, runner)
+			}
+		}
+	}
+
+	// Core rules are checked per runner. Plugin processes cannot serve concurrent checks,
+	// so each ruleset checks the runners sequentially and runs in parallel with other jobs.
+	checks := []func() error{}
+	coreRules := rules.NewRules(cfg)
+	for _, runner := range targets {
+		runner := runner
+		checks = append(checks, func() error {
+			for _, rule := range coreRules {
+				if err := rule.Check(runner); err != nil {
+					return fmt.Errorf("Failed to check `%s` rule; %w", rule.Name(), err)
+				}
+			}
+			return nil
+		})
+	}
+	for name, ruleset := range rulesetPlugin.RuleSets {
+		// Plugins may be launched for other directories with --hierarchical-config
+		if pluginCfg, exists := cfg.Plugins[name]; !exists || !pluginCfg.Enabled {
+			continue
+		}
+		ruleset := ruleset
+		checks = append(checks, func() error {
+			for _, runner := range targets {
+				if err := ruleset.Check(plugin.NewGRPCServer(runner, rootRunner, sources)); err != nil {
+					return fmt.Errorf("Failed to check ruleset; %w", err)
+				}
+			}
+			return nil
+		})
+	}
+	if err := runConcurrently(checks, jobs); err != nil {
+		return tflint.Issues{}, tflint.Issues{}, err
+	}
+	if cache != nil {
+		for _, runner := range targets {
+			if err := cache.Save(runner); err != nil {
+				log.Printf("[WARN] Failed to save the cache; %s", err)
+			}
+		}
+	}
+
+	rootRunner.EmitInvalidAnnotations(time.Now())
+	if cfg.ReportUnusedAnnotations {
+		ruleNames, err := (&rules.RuleSet{}).RuleNames()
+		if err != nil {
+			return tflint.Issues{}, tflint.Issues{}, fmt.Errorf("Failed to fetch rule names; %w", err)
+		}
+		for name, ruleset := range rulesetPlugin.RuleSets {
+			names, err := ruleset.RuleNames()
 			if err != nil {
-				cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to check ruleset; %w", err), cli.loader.Sources())
-				return ExitCodeError
+				return tflint.Issues{}, tflint.Issues{}, fmt.Errorf("Failed to fetch rule names from `%s` plugin; %w", name, err)
 			}
+			ruleNames = append(ruleNames, names...)
 		}
+		rootRunner.EmitUnusedAnnotations(ruleNames)
 	}
 
 	issues := tflint.Issues{}
+	suppressed := tflint.Issues{}
 	for _, runner := range runners {
 		issues = append(issues, runner.LookupIssues(filterFiles...)...)
+		suppressed = append(suppressed, runner.LookupSuppressedIssues(filterFiles...)...)
 	}
 
-	// Print issues
-	cli.formatter.Print(issues, nil, cli.loader.Sources())
+	// The order of emitted issues depends on the scheduling of checks
+	return issues.Sort(), suppressed.Sort(), nil
+}
+
+// runConcurrently calls the passed functions with up to the given number of goroutines.
+// It waits for all functions to return and returns the first error in the order of the functions.
+func runConcurrently(funcs []func() error, jobs int) error {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	errs := make([]error, len(funcs))
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	for i, fn := range funcs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, fn func() error) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn()
+		}(i, fn)
+	}
+	wg.Wait()
 
-	if len(issues) > 0 && !cfg.Force {
-		return ExitCodeIssuesFound
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	return ExitCodeOK
+// fix writes the fixes of the passed issues to files and inspects them again.
+// It returns the issues that remain after fixing and the suppressed issues.
+func (cli *CLI) fix(opts Options, cfg *tflint.Config, dir string, filterFiles []string, issues tflint.Issues, suppressed tflint.Issues, rulesetPlugin *plugin.Plugin, cache *tflint.Cache) (tflint.Issues, tflint.Issues, error) {
+	sources, fixed := tflint.ApplyFixes(issues, cli.loader.Sources())
+	if len(fixed) == 0 {
+		return issues, suppressed, nil
+	}
+
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+	for name, src := range sources {
+		info, err := fs.Stat(name)
+		if err != nil {
+			return tflint.Issues{}, tflint.Issues{}, fmt.Errorf("Failed to fix `%s`; %w", name, err)
+		}
+		if err := fs.WriteFile(name, src, info.Mode()); err != nil {
+			return tflint.Issues{}, tflint.Issues{}, fmt.Errorf("Failed to fix `%s`; %w", name, err)
+		}
+	}
+	log.Printf("[INFO] %d issue(s) fixed in %d file(s)", len(fixed), len(sources))
+
+	// The loader caches sources, so a new loader is required to read the fixed files.
+	if !cli.testMode {
+		var err error
+		cli.loader, err = tflint.NewLoader(fs, cfg)
+		if err != nil {
+			return tflint.Issues{}, tflint.Issues{}, fmt.Errorf("Failed to prepare loading; %w", err)
+		}
+		if opts.Recursive {
+			if err := cli.loader.SwitchRoot(dir); err != nil {
+				return tflint.Issues{}, tflint.Issues{}, fmt.Errorf("Failed to prepare loading; %w", err)
+			}
+		}
+	}
+	runners, err := cli.setupRunners(opts, cfg, dir)
+	if err != nil {
+		return tflint.Issues{}, tflint.Issues{}, err
+	}
+
+	return cli.inspectRunners(cfg, runners, rulesetPlugin, filterFiles, opts.Jobs, cache)
 }
 
 func (cli *CLI) setupRunners(opts Options, cfg *tflint.Config, dir string) ([]*tflint.Runner, error) {
@@ -156,3 +413,156 @@ func (cli *CLI) setupRunners(opts Options, cfg *tflint.Config, dir string) ([]*t
 
 	return append(runners, runner), nil
 }
+
+// newCache returns a cache for the results of the passed plugins.
+// Results are invalidated when plugins are added, removed or updated.
+func newCache(rulesetPlugin *plugin.Plugin) (*tflint.Cache, error) {
+	names := []string{}
+	for name := range rulesetPlugin.RuleSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	salt := ""
+	for _, name := range names {
+		version, err := rulesetPlugin.RuleSets[name].RuleSetVersion()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get `%s` plugin version; %w", name, err)
+		}
+		salt += fmt.Sprintf("plugin %s %s\n", name, version)
+	}
+
+	return tflint.NewCache(afero.Afero{Fs: afero.NewOsFs()}, salt), nil
+}
+
+// loadConfig loads the TFLint config and merges the CLI options into it.
+func loadConfig(opts Options) (*tflint.Config, error) {
+	cfg, err := tflint.LoadConfig(afero.Afero{Fs: afero.NewOsFs()}, opts.Config)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load TFLint config; %w", err)
+	}
+	if len(opts.Only) > 0 {
+		for _, rule := range cfg.Rules {
+			rule.Enabled = false
+		}
+	}
+	cfg.Merge(opts.toConfig())
+	return cfg, nil
+}
+
+// loadDirConfigs loads the config for the whole run and the configs for each directory.
+// With --hierarchical-config, settings for the whole run are taken from the current directory,
+// and each directory is inspected with its own config.
+func loadDirConfigs(opts Options, dirs []string) (*tflint.Config, map[string]*tflint.Config, error) {
+	cfg, err := loadDirConfig(opts, ".")
+	if err != nil {
+		return nil, nil, err
+	}
+	dirConfigs := map[string]*tflint.Config{}
+	for _, dir := range dirs {
+		dirConfigs[dir] = cfg
+		if opts.HierarchicalConfig {
+			dirConfigs[dir], err = loadDirConfig(opts, dir)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return cfg, dirConfigs, nil
+}
+
+// withPluginsOf returns the config that enables plugins enabled in any of the directory configs.
+// It is used to launch all plugins required for the inspection at once.
+func withPluginsOf(cfg *tflint.Config, dirConfigs map[string]*tflint.Config) *tflint.Config {
+	ret := *cfg
+	ret.Plugins = map[string]*tflint.PluginConfig{}
+	for name, plugin := range cfg.Plugins {
+		ret.Plugins[name] = plugin
+	}
+	for _, dirCfg := range dirConfigs {
+		for name, plugin := range dirCfg.Plugins {
+			if existing, exists := ret.Plugins[name]; !exists || (!existing.Enabled && plugin.Enabled) {
+				ret.Plugins[name] = plugin
+			}
+		}
+	}
+	return &ret
+}
+
+// loadDirConfig loads the TFLint config for the directory and merges the CLI options into it.
+// With --hierarchical-config, config files found from the directory up to the repository root
+// are merged over the file passed by --config, with nearer files taking precedence.
+// If no files are found, it falls back to the config loaded as usual.
+func loadDirConfig(opts Options, dir string) (*tflint.Config, error) {
+	if !opts.HierarchicalConfig {
+		return loadConfig(opts)
+	}
+
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+	files, err := tflint.FindConfigFiles(fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load TFLint config; %w", err)
+	}
+	if len(files) == 0 {
+		return loadConfig(opts)
+	}
+
+	cfg := tflint.EmptyConfig()
+	// The default config file is found in the hierarchy if it exists
+	if opts.Config != ".tflint.hcl" {
+		cfg, err = tflint.LoadConfig(fs, opts.Config)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load TFLint config; %w", err)
+		}
+	}
+	for _, file := range files {
+		fileCfg, err := tflint.LoadConfig(fs, file)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load TFLint config; %w", err)
+		}
+		cfg.Merge(fileCfg)
+	}
+
+	if len(opts.Only) > 0 {
+		for _, rule := range cfg.Rules {
+			rule.Enabled = false
+		}
+	}
+	cfg.Merge(opts.toConfig())
+	return cfg, nil
+}
+
+// applyPluginConfig applies the config to the plugins, and validates rule configs with all rulesets.
+func applyPluginConfig(cfg *tflint.Config, rulesetPlugin *plugin.Plugin) error {
+	rulesets := []tflint.RuleSet{&rules.RuleSet{}}
+	config := cfg.ToPluginConfig()
+	for name, ruleset := range rulesetPlugin.RuleSets {
+		if err := ruleset.ApplyGlobalConfig(config); err != nil {
+			return fmt.Errorf("Failed to apply global config to `%s` plugin; %w", name, err)
+		}
+		configSchema, err := ruleset.ConfigSchema()
+		if err != nil {
+			return fmt.Errorf("Failed to fetch config schema from `%s` plugin; %w", name, err)
+		}
+		content := &hclext.BodyContent{}
+		if plugin, exists := cfg.Plugins[name]; exists {
+			var diags hcl.Diagnostics
+			content, diags = plugin.Content(configSchema)
+			if diags.HasErrors() {
+				return fmt.Errorf("Failed to parse `%s` plugin config; %w", name, diags)
+			}
+		}
+		// Plugins cannot evaluate functions like env(), so they are evaluated in advance
+		content, sources := tflint.EvaluateConfigContent(content, cfg.Sources())
+		err = ruleset.ApplyConfig(content, sources)
+		if err != nil {
+			return fmt.Errorf("Failed to apply config to `%s` plugin; %w", name, err)
+		}
+
+		rulesets = append(rulesets, ruleset)
+	}
+	if err := cfg.ValidateRules(rulesets...); err != nil {
+		return fmt.Errorf("Failed to check rule config; %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/terraform-linters/tflint/plugin"
+	"github.com/terraform-linters/tflint/rules"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// ruleState describes whether a rule is enabled after merging the config file and CLI options.
+// Plugins do not expose the default states and severities of their rules,
+// so they are null unless configured explicitly.
+type ruleState struct {
+	Name           string `json:"name"`
+	RuleSet        string `json:"ruleset"`
+	Version        string `json:"version"`
+	DefaultEnabled *bool  `json:"default_enabled"`
+	Enabled        *bool  `json:"enabled"`
+	Reason         string `json:"reason"`
+	Severity       string `json:"severity,omitempty"`
+}
+
+type ruleStates struct {
+	Rules []ruleState `json:"rules"`
+}
+
+func (cli *CLI) listRules(opts Options) int {
+	if opts.Format != "" && opts.Format != "default" && opts.Format != "json" {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; --list-rules supports only the default and json formats"), map[string][]byte{})
+		return ExitCodeError
+	}
+
+	// Resolve the config in the same way as the inspection of the current directory
+	cfg, err := loadDirConfig(opts, ".")
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+
+	rulesetPlugin, err := plugin.Discovery(cfg)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to initialize plugins; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	defer rulesetPlugin.Clean()
+
+	states := []ruleState{}
+	for _, rule := range rules.DefaultRules {
+		defaultEnabled := rule.Enabled()
+		state := ruleState{Name: rule.Name(), RuleSet: "core", Version: tflint.Version, DefaultEnabled: &defaultEnabled}
+		state.Enabled, state.Reason = resolveRuleState(rule.Name(), state.DefaultEnabled, cfg, opts)
+		state.Severity = strings.ToLower(cfg.RuleSeverity(rule).String())
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+
+	names := []string{}
+	for name := range rulesetPlugin.RuleSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ruleset := rulesetPlugin.RuleSets[name]
+		version, err := ruleset.RuleSetVersion()
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to get `%s` plugin version; %w", name, err), map[string][]byte{})
+			return ExitCodeError
+		}
+		ruleNames, err := ruleset.RuleNames()
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to fetch rule names from `%s` plugin; %w", name, err), map[string][]byte{})
+			return ExitCodeError
+		}
+		sort.Strings(ruleNames)
+
+		for _, ruleName := range ruleNames {
+			state := ruleState{Name: ruleName, RuleSet: name, Version: version}
+			state.Enabled, state.Reason = resolveRuleState(ruleName, nil, cfg, opts)
+			if r := cfg.Rules[ruleName]; r != nil && r.Severity != "" {
+				state.Severity = r.Severity
+			}
+			states = append(states, state)
+		}
+	}
+
+	if opts.Format == "json" {
+		out, err := json.Marshal(ruleStates{Rules: states})
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to marshal rules; %w", err), map[string][]byte{})
+			return ExitCodeError
+		}
+		fmt.Fprintln(cli.outStream, string(out))
+		return ExitCodeOK
+	}
+
+	w := tabwriter.NewWriter(cli.outStream, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tRULESET\tVERSION\tDEFAULT\tENABLED\tSEVERITY\tREASON")
+	for _, state := range states {
+		severity := state.Severity
+		if severity == "" {
+			severity = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", state.Name, state.RuleSet, state.Version, formatOptionalBool(state.DefaultEnabled), formatOptionalBool(state.Enabled), severity, state.Reason)
+	}
+	w.Flush()
+
+	return ExitCodeOK
+}
+
+// resolveRuleState returns whether the rule is enabled and the reason.
+// It follows the same precedence as merging CLI options into the config file.
+// The CLI options are checked first, so the config after merging them can be passed.
+// If the default state is unknown and not overridden, it returns nil.
+func resolveRuleState(name string, defaultEnabled *bool, cfg *tflint.Config, opts Options) (*bool, string) {
+	enabled, disabled := true, false
+
+	if len(opts.Only) > 0 {
+		for _, rule := range opts.Only {
+			if rule == name {
+				return &enabled, "enabled via --only"
+			}
+		}
+		return &disabled, "disabled via --only"
+	}
+	for _, rule := range opts.DisableRules {
+		if rule == name {
+			return &disabled, "disabled via --disable-rule"
+		}
+	}
+	for _, rule := range opts.EnableRules {
+		if rule == name {
+			return &enabled, "enabled via --enable-rule"
+		}
+	}
+	if r := cfg.Rules[name]; r != nil {
+		if r.Enabled {
+			return &enabled, "enabled in the config file"
+		}
+		return &disabled, "disabled in the config file"
+	}
+	if cfg.DisabledByDefault {
+		return &disabled, "disabled via disabled_by_default"
+	}
+
+	switch {
+	case defaultEnabled == nil:
+		return nil, "default of the plugin"
+	case *defaultEnabled:
+		return defaultEnabled, "enabled by default"
+	default:
+		return defaultEnabled, "disabled by default"
+	}
+}
+
+func formatOptionalBool(b *bool) string {
+	if b == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%t", *b)
+}
@@ -9,23 +9,42 @@ import (
 
 // Options is an option specified by arguments.
 type Options struct {
-	Version       bool     `short:"v" long:"version" description:"Print TFLint version"`
-	Init          bool     `long:"init" description:"Install plugins"`
-	Langserver    bool     `long:"langserver" description:"Start language server"`
-	Format        string   `short:"f" long:"format" description:"Output format" choice:"default" choice:"json" choice:"checkstyle" choice:"junit" choice:"compact" choice:"sarif"`
-	Config        string   `short:"c" long:"config" description:"Config file name" value-name:"FILE" default:".tflint.hcl"`
-	IgnoreModules []string `long:"ignore-module" description:"Ignore module sources" value-name:"SOURCE"`
-	EnableRules   []string `long:"enable-rule" description:"Enable rules from the command line" value-name:"RULE_NAME"`
-	DisableRules  []string `long:"disable-rule" description:"Disable rules from the command line" value-name:"RULE_NAME"`
-	Only          []string `long:"only" description:"Enable only this rule, disabling all other defaults. Can be specified multiple times" value-name:"RULE_NAME"`
-	EnablePlugins []string `long:"enable-plugin" description:"Enable plugins from the command line" value-name:"PLUGIN_NAME"`
-	Varfiles      []string `long:"var-file" description:"Terraform variable file name" value-name:"FILE"`
-	Variables     []string `long:"var" description:"Set a Terraform variable" value-name:"'foo=bar'"`
-	Module        bool     `long:"module" description:"Inspect modules"`
-	Force         bool     `long:"force" description:"Return zero exit status even if issues found"`
-	Color         bool     `long:"color" description:"Enable colorized output"`
-	NoColor       bool     `long:"no-color" description:"Disable colorized output"`
-	LogLevel      string   `long:"loglevel" description:"Change the loglevel" choice:"trace" choice:"debug" choice:"info" choice:"warn" choice:"error"`
+	Version                 bool     `short:"v" long:"version" description:"Print TFLint version"`
+	Init                    bool     `long:"init" description:"Install plugins"`
+	Langserver              bool     `long:"langserver" description:"Start language server"`
+	ListRules               bool     `long:"list-rules" description:"List rules with the enabled states after merging the config file and CLI options"`
+	Explain                 string   `long:"explain" description:"Print the documentation of the rule" value-name:"RULE_NAME"`
+	Format                  string   `short:"f" long:"format" description:"Output format" choice:"default" choice:"json" choice:"checkstyle" choice:"junit" choice:"compact" choice:"sarif" choice:"github" choice:"gitlab" choice:"template"`
+	TemplateFile            string   `long:"template-file" description:"Go template file used by the template format" value-name:"FILE"`
+	Outputs                 []string `long:"output" description:"Write results to a file in addition to stdout. Can be specified multiple times" value-name:"FORMAT:PATH"`
+	Config                  string   `short:"c" long:"config" description:"Config file name" value-name:"FILE" default:".tflint.hcl"`
+	HierarchicalConfig      bool     `long:"hierarchical-config" description:"Merge .tflint.hcl files found from each inspected directory up to the repository root"`
+	IgnoreModules           []string `long:"ignore-module" description:"Ignore module sources" value-name:"SOURCE"`
+	EnableRules             []string `long:"enable-rule" description:"Enable rules from the command line" value-name:"RULE_NAME"`
+	DisableRules            []string `long:"disable-rule" description:"Disable rules from the command line" value-name:"RULE_NAME"`
+	Only                    []string `long:"only" description:"Enable only this rule, disabling all other defaults. Can be specified multiple times" value-name:"RULE_NAME"`
+	EnablePlugins           []string `long:"enable-plugin" description:"Enable plugins from the command line" value-name:"PLUGIN_NAME"`
+	Varfiles                []string `long:"var-file" description:"Terraform variable file name" value-name:"FILE"`
+	Variables               []string `long:"var" description:"Set a Terraform variable" value-name:"'foo=bar'"`
+	Excludes                []string `long:"exclude" description:"Exclude files matching the glob pattern from inspection. Can be specified multiple times" value-name:"PATTERN"`
+	HardExclude             bool     `long:"hard-exclude" description:"Do not load excluded files into the configuration at all"`
+	Module                  bool     `long:"module" description:"Inspect modules"`
+	Recursive               bool     `long:"recursive" description:"Inspect directories recursively. Each directory containing Terraform files is inspected as a root module"`
+	Force                   bool     `long:"force" description:"Return zero exit status even if issues found"`
+	MinimumFailureSeverity  string   `long:"minimum-failure-severity" description:"Sets minimum severity level for exiting with a non-zero error code" choice:"error" choice:"warning" choice:"notice"`
+	MinimumSeverity         string   `long:"minimum-severity" description:"Hide issues below the severity level" choice:"error" choice:"warning" choice:"notice"`
+	Fix                     bool     `long:"fix" description:"Fix issues automatically"`
+	ReportUnusedAnnotations bool     `long:"report-unused-annotations" description:"Report annotations that do not suppress any issues or refer to unknown rules"`
+	Baseline                string   `long:"baseline" description:"Report only issues not recorded in the baseline file" value-name:"FILE"`
+	WriteBaseline           string   `long:"write-baseline" description:"Record the current issues to the baseline file" value-name:"FILE"`
+	DiffBase                string   `long:"diff-base" description:"Report only issues on lines changed since the git ref" value-name:"REF"`
+	DiffFile                string   `long:"diff-file" description:"Report only issues on lines changed in the unified diff file" value-name:"FILE"`
+	Jobs                    int      `long:"jobs" description:"Number of rule checks to run in parallel" value-name:"N" default:"1"`
+	Cache                   bool     `long:"cache" description:"Reuse results of unchanged modules from the previous run"`
+	Watch                   bool     `long:"watch" description:"Inspect again whenever files are changed"`
+	Color                   bool     `long:"color" description:"Enable colorized output"`
+	NoColor                 bool     `long:"no-color" description:"Disable colorized output"`
+	LogLevel                string   `long:"loglevel" description:"Change the loglevel" choice:"trace" choice:"debug" choice:"info" choice:"warn" choice:"error"`
 }
 
 func (opts *Options) toConfig() *tflint.Config {
@@ -47,8 +66,20 @@ func (opts *Options) toConfig() *tflint.Config {
 	}
 
 	log.Printf("[DEBUG] CLI Options")
+	log.Printf("[DEBUG]   HierarchicalConfig: %t", opts.HierarchicalConfig)
 	log.Printf("[DEBUG]   Module: %t", opts.Module)
+	log.Printf("[DEBUG]   Recursive: %t", opts.Recursive)
 	log.Printf("[DEBUG]   Force: %t", opts.Force)
+	log.Printf("[DEBUG]   MinimumFailureSeverity: %s", opts.MinimumFailureSeverity)
+	log.Printf("[DEBUG]   MinimumSeverity: %s", opts.MinimumSeverity)
+	log.Printf("[DEBUG]   Fix: %t", opts.Fix)
+	log.Printf("[DEBUG]   ReportUnusedAnnotations: %t", opts.ReportUnusedAnnotations)
+	log.Printf("[DEBUG]   Baseline: %s", opts.Baseline)
+	log.Printf("[DEBUG]   WriteBaseline: %s", opts.WriteBaseline)
+	log.Printf("[DEBUG]   DiffBase: %s", opts.DiffBase)
+	log.Printf("[DEBUG]   DiffFile: %s", opts.DiffFile)
+	log.Printf("[DEBUG]   Cache: %t", opts.Cache)
+	log.Printf("[DEBUG]   Watch: %t", opts.Watch)
 	log.Printf("[DEBUG]   IgnoreModules:")
 	for name, ignore := range ignoreModules {
 		log.Printf("[DEBUG]     %s: %t", name, ignore)
@@ -57,9 +88,13 @@ func (opts *Options) toConfig() *tflint.Config {
 	log.Printf("[DEBUG]   DisableRules: %s", strings.Join(opts.DisableRules, ", "))
 	log.Printf("[DEBUG]   Only: %s", strings.Join(opts.Only, ", "))
 	log.Printf("[DEBUG]   EnablePlugins: %s", strings.Join(opts.EnablePlugins, ", "))
+	log.Printf("[DEBUG]   Excludes: %s", strings.Join(opts.Excludes, ", "))
+	log.Printf("[DEBUG]   HardExclude: %t", opts.HardExclude)
 	log.Printf("[DEBUG]   Varfiles: %s", strings.Join(opts.Varfiles, ", "))
 	log.Printf("[DEBUG]   Variables: %s", strings.Join(opts.Variables, ", "))
 	log.Printf("[DEBUG]   Format: %s", opts.Format)
+	log.Printf("[DEBUG]   TemplateFile: %s", opts.TemplateFile)
+	log.Printf("[DEBUG]   Outputs: %s", strings.Join(opts.Outputs, ", "))
 
 	rules := map[string]*tflint.RuleConfig{}
 	if len(opts.Only) > 0 {
@@ -101,14 +136,18 @@ func (opts *Options) toConfig() *tflint.Config {
 	}
 
 	return &tflint.Config{
-		Module:            opts.Module,
-		Force:             opts.Force,
-		IgnoreModules:     ignoreModules,
-		Varfiles:          varfiles,
-		Variables:         opts.Variables,
-		DisabledByDefault: len(opts.Only) > 0,
-		Format:            opts.Format,
-		Rules:             rules,
-		Plugins:           plugins,
+		Module:                  opts.Module,
+		Force:                   opts.Force,
+		IgnoreModules:           ignoreModules,
+		Varfiles:                varfiles,
+		Variables:               opts.Variables,
+		DisabledByDefault:       len(opts.Only) > 0,
+		Format:                  opts.Format,
+		MinimumFailureSeverity:  opts.MinimumFailureSeverity,
+		ReportUnusedAnnotations: opts.ReportUnusedAnnotations,
+		ExcludePaths:            opts.Excludes,
+		HardExclude:             opts.HardExclude,
+		Rules:                   rules,
+		Plugins:                 plugins,
 	}
 }
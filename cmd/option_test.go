@@ -49,6 +49,21 @@ func Test_toConfig(t *testing.T) {
 				Plugins:           map[string]*tflint.PluginConfig{},
 			},
 		},
+		{
+			Name:    "--minimum-failure-severity",
+			Command: "./tflint --minimum-failure-severity=warning",
+			Expected: &tflint.Config{
+				Module:                 false,
+				Force:                  false,
+				IgnoreModules:          map[string]bool{},
+				Varfiles:               []string{},
+				Variables:              []string{},
+				DisabledByDefault:      false,
+				MinimumFailureSeverity: "warning",
+				Rules:                  map[string]*tflint.RuleConfig{},
+				Plugins:                map[string]*tflint.PluginConfig{},
+			},
+		},
 		{
 			Name:    "--ignore-module",
 			Command: "./tflint --ignore-module module1,module2",
@@ -214,6 +229,22 @@ func Test_toConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:    "--exclude",
+			Command: "./tflint --exclude *.generated.tf --exclude provider_override.tf --hard-exclude",
+			Expected: &tflint.Config{
+				Module:            false,
+				Force:             false,
+				IgnoreModules:     map[string]bool{},
+				Varfiles:          []string{},
+				Variables:         []string{},
+				DisabledByDefault: false,
+				ExcludePaths:      []string{"*.generated.tf", "provider_override.tf"},
+				HardExclude:       true,
+				Rules:             map[string]*tflint.RuleConfig{},
+				Plugins:           map[string]*tflint.PluginConfig{},
+			},
+		},
 		{
 			Name:    "--format",
 			Command: "./tflint --format compact",
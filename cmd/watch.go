@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/terraform-linters/tflint/plugin"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// watchInterval is the interval to poll files. Changes are inspected after files are
+// unchanged for an interval, since editors may write files several times on save.
+const watchInterval = 500 * time.Millisecond
+
+// fileState is the state of a file used to detect changes
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// watch inspects the directory every time files are changed until interrupted.
+// Plugin processes and the loader are kept alive, while the config and files are loaded again for each inspection.
+func (cli *CLI) watch(opts Options, dirs []string, filterFiles []string) int {
+	// Outputs are written once per run, and fixes and recorded baselines would trigger another inspection
+	conflicts := map[string]bool{
+		"--fix":            opts.Fix,
+		"--recursive":      opts.Recursive,
+		"--output":         len(opts.Outputs) > 0,
+		"--write-baseline": opts.WriteBaseline != "",
+	}
+	for _, name := range []string{"--fix", "--recursive", "--output", "--write-baseline"} {
+		if conflicts[name] {
+			cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to parse CLI options; --watch cannot be used with %s", name), map[string][]byte{})
+			return ExitCodeError
+		}
+	}
+
+	cfg, dirConfigs, err := loadDirConfigs(opts, dirs)
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		return ExitCodeError
+	}
+	rulesetPlugin, err := plugin.Discovery(withPluginsOf(cfg, dirConfigs))
+	if err != nil {
+		cli.formatter.Print(tflint.Issues{}, fmt.Errorf("Failed to initialize plugins; %w", err), map[string][]byte{})
+		return ExitCodeError
+	}
+	defer rulesetPlugin.Clean()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	stop := make(chan struct{})
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	cli.watchLoop(opts, dirs[0], filterFiles, rulesetPlugin, watchInterval, stop)
+	return ExitCodeOK
+}
+
+// watchLoop inspects the directory and waits for changes repeatedly until the stop channel is closed.
+// Each inspection is the same as a normal run, including the format, baseline, diff and severity options.
+// After the first inspection, it also prints the numbers of new and fixed issues since the last inspection.
+func (cli *CLI) watchLoop(opts Options, dir string, filterFiles []string, rulesetPlugin *plugin.Plugin, interval time.Duration, stop <-chan struct{}) {
+	var previous map[string]int
+	for {
+		// Take a snapshot before inspection to catch changes made during inspection
+		// Extended config files are watched as well as the config file
+		files := []string{opts.Config}
+		cfg, dirConfigs, err := loadDirConfigs(opts, []string{dir})
+		if err == nil {
+			for _, config := range []*tflint.Config{cfg, dirConfigs[dir]} {
+				for name := range config.Sources() {
+					files = append(files, name)
+				}
+				files = append(files, config.Varfiles...)
+			}
+		}
+		snapshot := snapshotFiles(dir, files)
+
+		if err != nil {
+			cli.formatter.Print(tflint.Issues{}, err, map[string][]byte{})
+		} else if status, issues, sources := cli.inspectWithPlugin(opts, cfg, dirConfigs, []string{dir}, filterFiles, rulesetPlugin); status != ExitCodeError {
+			current := map[string]int{}
+			for _, fingerprint := range tflint.Fingerprints(issues, sources) {
+				current[fingerprint]++
+			}
+			if previous != nil {
+				introduced, fixed := compareFingerprints(previous, current)
+				fmt.Fprintf(cli.progressStream(), "%d new issue(s), %d fixed issue(s) since the last run\n", introduced, fixed)
+			}
+			previous = current
+		}
+		fmt.Fprintln(cli.progressStream(), "Watching for changes...")
+
+		changed, ok := waitForChanges(snapshot, interval, stop, func() map[string]fileState {
+			return snapshotFiles(dir, files)
+		})
+		if !ok {
+			return
+		}
+		fmt.Fprintf(cli.progressStream(), "\n%s changed. Inspecting again...\n\n", strings.Join(changed, ", "))
+	}
+}
+
+// progressStream returns the stream to print progress messages of --watch.
+// Results in formats other than default are meant to be parsed, so the messages are printed to stderr.
+func (cli *CLI) progressStream() io.Writer {
+	if cli.formatter.Format == "" || cli.formatter.Format == "default" {
+		return cli.outStream
+	}
+	return cli.errStream
+}
+
+// compareFingerprints returns the numbers of issues introduced and fixed between the two runs.
+// Fingerprints are counted, because the same issue can be found more than once.
+func compareFingerprints(previous map[string]int, current map[string]int) (int, int) {
+	introduced, fixed := 0, 0
+	for fingerprint, count := range current {
+		if count > previous[fingerprint] {
+			introduced += count - previous[fingerprint]
+		}
+	}
+	for fingerprint, count := range previous {
+		if count > current[fingerprint] {
+			fixed += count - current[fingerprint]
+		}
+	}
+	return introduced, fixed
+}
+
+// waitForChanges polls files until they are changed from the snapshot and then stay unchanged for an interval.
+// It returns the changed files, or false if the stop channel is closed.
+func waitForChanges(snapshot map[string]fileState, interval time.Duration, stop <-chan struct{}, take func() map[string]fileState) ([]string, bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var current map[string]fileState
+	for {
+		select {
+		case <-stop:
+			return []string{}, false
+		case <-ticker.C:
+		}
+
+		next := take()
+		if current != nil && len(changedFiles(current, next)) == 0 {
+			return changedFiles(snapshot, current), true
+		}
+		if len(changedFiles(snapshot, next)) > 0 {
+			current = next
+		} else {
+			current = nil
+		}
+	}
+}
+
+// snapshotFiles returns the states of Terraform files and values files in the directory, and the passed files.
+// Hidden directories like .terraform are not watched.
+func snapshotFiles(dir string, files []string) map[string]fileState {
+	ret := map[string]fileState{}
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, suffix := range []string{".tf", ".tf.json", ".tfvars", ".tfvars.json"} {
+			if strings.HasSuffix(path, suffix) {
+				if info, err := entry.Info(); err == nil {
+					ret[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+				}
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[WARN] Failed to walk `%s`; %s", dir, err)
+	}
+
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			ret[filepath.Clean(file)] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+
+	return ret
+}
+
+// changedFiles returns the files added, removed or modified between the two snapshots
+func changedFiles(before map[string]fileState, after map[string]fileState) []string {
+	ret := []string{}
+	for path, state := range after {
+		if prev, exists := before[path]; !exists || prev != state {
+			ret = append(ret, path)
+		}
+	}
+	for path := range before {
+		if _, exists := after[path]; !exists {
+			ret = append(ret, path)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,48 @@
+// Package docs embeds the documentation of core rules into the binary,
+// so that it is available without network access.
+package docs
+
+import (
+	"embed"
+	"strings"
+)
+
+//go:embed rules/terraform_*.md
+var ruleDocs embed.FS
+
+// RuleDoc returns the Markdown documentation of the core rule.
+func RuleDoc(name string) (string, bool) {
+	src, err := ruleDocs.ReadFile("rules/" + name + ".md")
+	if err != nil {
+		return "", false
+	}
+	return string(src), true
+}
+
+// RuleSummary returns the first paragraph of the documentation of the core rule.
+func RuleSummary(name string) (string, bool) {
+	doc, exists := RuleDoc(name)
+	if !exists {
+		return "", false
+	}
+	return Summary(doc), true
+}
+
+// Summary returns the first paragraph following the title of the Markdown documentation.
+func Summary(doc string) string {
+	lines := []string{}
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") {
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " ")
+}
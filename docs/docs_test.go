@@ -0,0 +1,54 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint/rules"
+)
+
+func Test_RuleDoc(t *testing.T) {
+	for _, rule := range rules.DefaultRules {
+		if _, exists := RuleDoc(rule.Name()); !exists {
+			t.Fatalf("The documentation of `%s` is not embedded", rule.Name())
+		}
+		if summary, _ := RuleSummary(rule.Name()); summary == "" {
+			t.Fatalf("The documentation of `%s` has no summary", rule.Name())
+		}
+	}
+
+	if _, exists := RuleDoc("not_found"); exists {
+		t.Fatal("Expected the documentation of unknown rules does not exist")
+	}
+}
+
+func Test_Summary(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Doc      string
+		Expected string
+	}{
+		{
+			Name:     "first paragraph",
+			Doc:      "# rule\n\nDisallow foo.\nUse bar instead.\n\n## Example\n",
+			Expected: "Disallow foo. Use bar instead.",
+		},
+		{
+			Name:     "no blank line after title",
+			Doc:      "# rule\nDisallow foo.\n## Example\n",
+			Expected: "Disallow foo.",
+		},
+		{
+			Name:     "no paragraph",
+			Doc:      "# rule\n\n## Example\n",
+			Expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := Summary(tc.Doc); got != tc.Expected {
+				t.Fatalf("Expected `%s`, but got `%s`", tc.Expected, got)
+			}
+		})
+	}
+}
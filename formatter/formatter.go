@@ -3,7 +3,9 @@ package formatter
 import (
 	"fmt"
 	"io"
+	"text/template"
 
+	"github.com/fatih/color"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint/tflint"
 )
@@ -14,10 +16,50 @@ type Formatter struct {
 	Stderr  io.Writer
 	Format  string
 	NoColor bool
+	// SuppressedIssues are issues suppressed by annotations.
+	// They are printed with justifications in formats that support suppressions.
+	SuppressedIssues tflint.Issues
+	// Outputs are additional destinations. The results are written to each of them
+	// in its own format, in addition to Stdout.
+	Outputs []*Output
+	// Template is used for the `template` format. See NewTemplate.
+	Template *template.Template
+}
+
+// Output is an additional destination of the results
+type Output struct {
+	Format string
+	Writer io.Writer
 }
 
 // Print outputs the given issues and errors according to configured format
 func (f *Formatter) Print(issues tflint.Issues, err error, sources map[string][]byte) {
+	f.print(issues, err, sources)
+
+	if len(f.Outputs) == 0 {
+		return
+	}
+	// Outputs are usually files, so they are always printed without color
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	// Errors are already printed to stderr by the primary format. Formats that can hold errors,
+	// like JSON and SARIF, still include them in outputs, but anything else is discarded to keep outputs valid.
+	for _, output := range f.Outputs {
+		formatter := &Formatter{
+			Stdout:           output.Writer,
+			Stderr:           io.Discard,
+			Format:           output.Format,
+			NoColor:          true,
+			SuppressedIssues: f.SuppressedIssues,
+			Template:         f.Template,
+		}
+		formatter.print(issues, err, sources)
+	}
+}
+
+func (f *Formatter) print(issues tflint.Issues, err error, sources map[string][]byte) {
 	switch f.Format {
 	case "default":
 		f.prettyPrint(issues, err, sources)
@@ -31,6 +73,12 @@ func (f *Formatter) Print(issues tflint.Issues, err error, sources map[string][]
 		f.compactPrint(issues, err, sources)
 	case "sarif":
 		f.sarifPrint(issues, err)
+	case "github":
+		f.githubPrint(issues, err)
+	case "gitlab":
+		f.gitlabPrint(issues, err, sources)
+	case "template":
+		f.templatePrint(issues, err, sources)
 	default:
 		f.prettyPrint(issues, err, sources)
 	}
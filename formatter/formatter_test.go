@@ -1,6 +1,14 @@
 package formatter
 
-import "github.com/terraform-linters/tflint/tflint"
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
 
 type testRule struct{}
 
@@ -19,3 +27,72 @@ func (r *testRule) Severity() tflint.Severity {
 func (r *testRule) Link() string {
 	return "https://github.com"
 }
+
+func Test_Print_outputs(t *testing.T) {
+	issues := tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+			},
+		},
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	jsonOut := &bytes.Buffer{}
+	compactOut := &bytes.Buffer{}
+	formatter := &Formatter{
+		Stdout: stdout,
+		Stderr: stderr,
+		Format: "compact",
+		Outputs: []*Output{
+			{Format: "json", Writer: jsonOut},
+			{Format: "compact", Writer: compactOut},
+		},
+	}
+
+	formatter.Print(issues, nil, map[string][]byte{})
+
+	if !strings.Contains(stdout.String(), "test.tf:1:1: Error - test (test_rule)") {
+		t.Fatalf("Unexpected stdout: %s", stdout.String())
+	}
+	if !strings.HasPrefix(jsonOut.String(), `{"issues":[{"rule":{"name":"test_rule"`) {
+		t.Fatalf("Unexpected JSON output: %s", jsonOut.String())
+	}
+	if compactOut.String() != stdout.String() {
+		t.Fatalf("Expected the compact output is the same as stdout: expected=%s, got=%s", stdout.String(), compactOut.String())
+	}
+}
+
+func Test_Print_outputsWithError(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	jsonOut := &bytes.Buffer{}
+	junitOut := &bytes.Buffer{}
+	formatter := &Formatter{
+		Stdout:  stdout,
+		Stderr:  stderr,
+		Format:  "default",
+		NoColor: true,
+		Outputs: []*Output{
+			{Format: "json", Writer: jsonOut},
+			{Format: "junit", Writer: junitOut},
+		},
+	}
+
+	formatter.Print(tflint.Issues{}, errors.New("Failed to work; I don't feel like working"), map[string][]byte{})
+
+	if count := strings.Count(stderr.String(), "Failed to work"); count != 1 {
+		t.Fatalf("Expected the error is printed to stderr once, but printed %d times: %s", count, stderr.String())
+	}
+	if !strings.Contains(jsonOut.String(), `"errors":[{"message":"Failed to work; I don't feel like working","severity":"error"}]`) {
+		t.Fatalf("Expected the error is written to the JSON output, but got %s", jsonOut.String())
+	}
+	if strings.Contains(junitOut.String(), "Failed to work") {
+		t.Fatalf("Expected the error is not written to the JUnit output, but got %s", junitOut.String())
+	}
+}
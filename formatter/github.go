@@ -0,0 +1,76 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// githubPrint outputs issues as GitHub Actions workflow commands.
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+func (f *Formatter) githubPrint(issues tflint.Issues, appErr error) {
+	for _, issue := range issues {
+		var command string
+		switch issue.Rule.Severity() {
+		case tflint.ERROR:
+			command = "error"
+		case tflint.WARNING:
+			command = "warning"
+		case tflint.NOTICE:
+			command = "notice"
+		default:
+			panic(fmt.Errorf("Unexpected lint type: %s", issue.Rule.Severity()))
+		}
+
+		fmt.Fprintln(f.Stdout, githubCommand(command, &issue.Range, issue.Rule.Name(), issue.Message))
+	}
+
+	if appErr != nil {
+		var diags hcl.Diagnostics
+		if errors.As(appErr, &diags) {
+			for _, diag := range diags {
+				fmt.Fprintln(f.Stdout, githubCommand(fromHclSeverity(diag.Severity), diag.Subject, diag.Summary, diag.Detail))
+			}
+		} else {
+			fmt.Fprintln(f.Stdout, githubCommand("error", nil, "", appErr.Error()))
+		}
+	}
+}
+
+func githubCommand(command string, rng *hcl.Range, title string, message string) string {
+	properties := []string{}
+	if rng != nil && rng.Filename != "" {
+		properties = append(properties, "file="+escapeGithubProperty(rng.Filename))
+		if rng.Start.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", rng.Start.Line))
+		}
+		if rng.End.Line > 0 {
+			properties = append(properties, fmt.Sprintf("endLine=%d", rng.End.Line))
+		}
+		if rng.Start.Column > 0 {
+			properties = append(properties, fmt.Sprintf("col=%d", rng.Start.Column))
+		}
+		if rng.End.Column > 0 {
+			properties = append(properties, fmt.Sprintf("endColumn=%d", rng.End.Column))
+		}
+	}
+	if title != "" {
+		properties = append(properties, "title="+escapeGithubProperty(title))
+	}
+
+	if len(properties) == 0 {
+		return fmt.Sprintf("::%s::%s", command, escapeGithubData(message))
+	}
+	return fmt.Sprintf("::%s %s::%s", command, strings.Join(properties, ","), escapeGithubData(message))
+}
+
+func escapeGithubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGithubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,88 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_githubPrint(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Issues tflint.Issues
+		Error  error
+		Stdout string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "",
+		},
+		{
+			Name: "issues",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+				},
+				{
+					Rule:    &testRule{},
+					Message: "100% of\nlines",
+					Range: hcl.Range{
+						Filename: "dir,name/test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+						End:      hcl.Pos{Line: 4, Column: 1, Byte: 30},
+					},
+				},
+			},
+			Stdout: `::error file=test.tf,line=1,endLine=1,col=1,endColumn=4,title=test_rule::test
+::error file=dir%2Cname/test.tf,line=2,endLine=4,col=3,endColumn=1,title=test_rule::100%25 of%0Alines
+`,
+		},
+		{
+			Name:   "error",
+			Error:  fmt.Errorf("Failed to work; %w", errors.New("I don't feel like working")),
+			Stdout: "::error::Failed to work; I don't feel like working\n",
+		},
+		{
+			Name: "diagnostics",
+			Error: fmt.Errorf(
+				"babel fish confused; %w",
+				hcl.Diagnostics{
+					&hcl.Diagnostic{
+						Severity: hcl.DiagWarning,
+						Summary:  "summary",
+						Detail:   "detail",
+						Subject: &hcl.Range{
+							Filename: "filename",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 5, Column: 1, Byte: 4},
+						},
+					},
+				},
+			),
+			Stdout: "::warning file=filename,line=1,endLine=5,col=1,endColumn=1,title=summary::detail\n",
+		},
+	}
+
+	for _, tc := range cases {
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "github"}
+
+		formatter.Print(tc.Issues, tc.Error, map[string][]byte{})
+
+		if stdout.String() != tc.Stdout {
+			t.Fatalf("Failed %s test: expected=%s, stdout=%s", tc.Name, tc.Stdout, stdout.String())
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// gitlabIssue is a temporary structure for converting TFLint issues to GitLab Code Quality reports.
+// See https://docs.gitlab.com/ee/ci/testing/code_quality.html#implement-a-custom-tool
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+func (f *Formatter) gitlabPrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	issues = issues.Sort()
+	ret := make([]gitlabIssue, len(issues))
+
+	// Fingerprints do not depend on line numbers, so that GitLab can compare
+	// reports between branches. Duplicates are numbered to keep them unique.
+	fingerprints := tflint.Fingerprints(issues, sources)
+	seen := map[string]int{}
+
+	for idx, issue := range issues {
+		fingerprint := fingerprints[idx]
+		if count := seen[fingerprints[idx]]; count > 0 {
+			fingerprint = fmt.Sprintf("%s-%d", fingerprint, count)
+		}
+		seen[fingerprints[idx]]++
+
+		ret[idx] = gitlabIssue{
+			Description: issue.Message,
+			CheckName:   issue.Rule.Name(),
+			Fingerprint: fingerprint,
+			Severity:    toGitlabSeverity(issue.Rule.Severity()),
+			Location: gitlabLocation{
+				Path:  filepath.ToSlash(issue.Range.Filename),
+				Lines: gitlabLines{Begin: issue.Range.Start.Line, End: issue.Range.End.Line},
+			},
+		}
+	}
+
+	out, err := json.Marshal(ret)
+	if err != nil {
+		fmt.Fprint(f.Stderr, err)
+	}
+	fmt.Fprint(f.Stdout, string(out))
+
+	if appErr != nil {
+		f.prettyPrintErrors(appErr, sources)
+	}
+}
+
+func toGitlabSeverity(severity tflint.Severity) string {
+	switch severity {
+	case tflint.ERROR:
+		return "major"
+	case tflint.WARNING:
+		return "minor"
+	case tflint.NOTICE:
+		return "info"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
@@ -0,0 +1,91 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_gitlabPrint(t *testing.T) {
+	issue := func(line int, start int, end int) *tflint.Issue {
+		return &tflint.Issue{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: line, Column: 1, Byte: start},
+				End:      hcl.Pos{Line: line, Column: 1 + end - start, Byte: end},
+			},
+		}
+	}
+
+	cases := []struct {
+		Name    string
+		Issues  tflint.Issues
+		Sources map[string][]byte
+		Stdout  string
+	}{
+		{
+			Name:   "no issues",
+			Issues: tflint.Issues{},
+			Stdout: "[]",
+		},
+		{
+			Name:    "issues",
+			Issues:  tflint.Issues{issue(1, 0, 3), issue(2, 4, 7)},
+			Sources: map[string][]byte{"test.tf": []byte("foo\nfoo\n")},
+			Stdout:  `[{"description":"test","check_name":"test_rule","fingerprint":"FP","severity":"major","location":{"path":"test.tf","lines":{"begin":1,"end":1}}},{"description":"test","check_name":"test_rule","fingerprint":"FP-1","severity":"major","location":{"path":"test.tf","lines":{"begin":2,"end":2}}}]`,
+		},
+	}
+
+	for _, tc := range cases {
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "gitlab"}
+
+		formatter.Print(tc.Issues, nil, tc.Sources)
+
+		expected := tc.Stdout
+		if len(tc.Issues) > 0 {
+			expected = strings.ReplaceAll(expected, "FP", tflint.Fingerprints(tc.Issues[:1], tc.Sources)[0])
+		}
+		if stdout.String() != expected {
+			t.Fatalf("Failed %s test: expected=%s, stdout=%s", tc.Name, expected, stdout.String())
+		}
+	}
+}
+
+func Test_gitlabPrint_stableFingerprint(t *testing.T) {
+	fingerprint := func(src string, line int, start int) string {
+		stdout := &bytes.Buffer{}
+		formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, Format: "gitlab"}
+		formatter.Print(tflint.Issues{
+			{
+				Rule:    &testRule{},
+				Message: "test",
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: line, Column: 3, Byte: start},
+					End:      hcl.Pos{Line: line, Column: 6, Byte: start + 3},
+				},
+			},
+		}, nil, map[string][]byte{"test.tf": []byte(src)})
+
+		var ret []gitlabIssue
+		if err := json.Unmarshal(stdout.Bytes(), &ret); err != nil {
+			t.Fatal(err)
+		}
+		return ret[0].Fingerprint
+	}
+
+	// Lines are inserted before the block
+	before := fingerprint("locals {\n  foo = 1\n}\n", 2, 11)
+	after := fingerprint("# comment\n\nlocals {\n  foo = 1\n}\n", 4, 22)
+	if before != after {
+		t.Fatalf("Expected the fingerprint is not changed: before=%s, after=%s", before, after)
+	}
+}
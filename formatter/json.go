@@ -15,6 +15,14 @@ type JSONIssue struct {
 	Message string      `json:"message"`
 	Range   JSONRange   `json:"range"`
 	Callers []JSONRange `json:"callers"`
+	// Suppressions is set only for issues suppressed by annotations
+	Suppressions []JSONSuppression `json:"suppressions,omitempty"`
+}
+
+// JSONSuppression is a temporary structure for converting suppressions to JSON.
+type JSONSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
 }
 
 // JSONRule is a temporary structure for converting TFLint rules to JSON.
@@ -47,59 +55,26 @@ type JSONError struct {
 
 // JSONOutput is a temporary structure for converting to JSON.
 type JSONOutput struct {
-	Issues []JSONIssue `json:"issues"`
-	Errors []JSONError `json:"errors"`
+	Issues           []JSONIssue `json:"issues"`
+	SuppressedIssues []JSONIssue `json:"suppressed_issues,omitempty"`
+	Errors           []JSONError `json:"errors"`
 }
 
 func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error) {
 	ret := &JSONOutput{Issues: make([]JSONIssue, len(issues)), Errors: []JSONError{}}
 
 	for idx, issue := range issues.Sort() {
-		ret.Issues[idx] = JSONIssue{
-			Rule: JSONRule{
-				Name:     issue.Rule.Name(),
-				Severity: toSeverity(issue.Rule.Severity()),
-				Link:     issue.Rule.Link(),
-			},
-			Message: issue.Message,
-			Range: JSONRange{
-				Filename: issue.Range.Filename,
-				Start:    JSONPos{Line: issue.Range.Start.Line, Column: issue.Range.Start.Column},
-				End:      JSONPos{Line: issue.Range.End.Line, Column: issue.Range.End.Column},
-			},
-			Callers: make([]JSONRange, len(issue.Callers)),
-		}
-		for i, caller := range issue.Callers {
-			ret.Issues[idx].Callers[i] = JSONRange{
-				Filename: caller.Filename,
-				Start:    JSONPos{Line: caller.Start.Line, Column: caller.Start.Column},
-				End:      JSONPos{Line: caller.End.Line, Column: caller.End.Column},
-			}
+		ret.Issues[idx] = toJSONIssue(issue)
+	}
+	if len(f.SuppressedIssues) > 0 {
+		ret.SuppressedIssues = make([]JSONIssue, len(f.SuppressedIssues))
+		for idx, issue := range f.SuppressedIssues.Sort() {
+			ret.SuppressedIssues[idx] = toJSONIssue(issue)
 		}
 	}
 
 	if appErr != nil {
-		var diags hcl.Diagnostics
-		if errors.As(appErr, &diags) {
-			ret.Errors = make([]JSONError, len(diags))
-			for idx, diag := range diags {
-				ret.Errors[idx] = JSONError{
-					Severity: fromHclSeverity(diag.Severity),
-					Summary:  diag.Summary,
-					Message:  diag.Detail,
-					Range: &JSONRange{
-						Filename: diag.Subject.Filename,
-						Start:    JSONPos{Line: diag.Subject.Start.Line, Column: diag.Subject.Start.Column},
-						End:      JSONPos{Line: diag.Subject.End.Line, Column: diag.Subject.End.Column},
-					},
-				}
-			}
-		} else {
-			ret.Errors = []JSONError{{
-				Severity: toSeverity(tflint.ERROR),
-				Message:  appErr.Error(),
-			}}
-		}
+		ret.Errors = toJSONErrors(appErr)
 	}
 
 	out, err := json.Marshal(ret)
@@ -108,3 +83,58 @@ func (f *Formatter) jsonPrint(issues tflint.Issues, appErr error) {
 	}
 	fmt.Fprint(f.Stdout, string(out))
 }
+
+func toJSONIssue(issue *tflint.Issue) JSONIssue {
+	ret := JSONIssue{
+		Rule: JSONRule{
+			Name:     issue.Rule.Name(),
+			Severity: toSeverity(issue.Rule.Severity()),
+			Link:     issue.Rule.Link(),
+		},
+		Message: issue.Message,
+		Range: JSONRange{
+			Filename: issue.Range.Filename,
+			Start:    JSONPos{Line: issue.Range.Start.Line, Column: issue.Range.Start.Column},
+			End:      JSONPos{Line: issue.Range.End.Line, Column: issue.Range.End.Column},
+		},
+		Callers: make([]JSONRange, len(issue.Callers)),
+	}
+	for i, caller := range issue.Callers {
+		ret.Callers[i] = JSONRange{
+			Filename: caller.Filename,
+			Start:    JSONPos{Line: caller.Start.Line, Column: caller.Start.Column},
+			End:      JSONPos{Line: caller.End.Line, Column: caller.End.Column},
+		}
+	}
+	if issue.Suppression != nil {
+		ret.Suppressions = []JSONSuppression{
+			{Kind: "inSource", Justification: issue.Suppression.Justification()},
+		}
+	}
+	return ret
+}
+
+func toJSONErrors(appErr error) []JSONError {
+	var diags hcl.Diagnostics
+	if errors.As(appErr, &diags) {
+		ret := make([]JSONError, len(diags))
+		for idx, diag := range diags {
+			ret[idx] = JSONError{
+				Severity: fromHclSeverity(diag.Severity),
+				Summary:  diag.Summary,
+				Message:  diag.Detail,
+				Range: &JSONRange{
+					Filename: diag.Subject.Filename,
+					Start:    JSONPos{Line: diag.Subject.Start.Line, Column: diag.Subject.Start.Column},
+					End:      JSONPos{Line: diag.Subject.End.Line, Column: diag.Subject.End.Column},
+				},
+			}
+		}
+		return ret
+	}
+
+	return []JSONError{{
+		Severity: toSeverity(tflint.ERROR),
+		Message:  appErr.Error(),
+	}}
+}
@@ -12,16 +12,37 @@ import (
 
 func Test_jsonPrint(t *testing.T) {
 	cases := []struct {
-		Name   string
-		Issues tflint.Issues
-		Error  error
-		Stdout string
+		Name       string
+		Issues     tflint.Issues
+		Suppressed tflint.Issues
+		Error      error
+		Stdout     string
 	}{
 		{
 			Name:   "no issues",
 			Issues: tflint.Issues{},
 			Stdout: `{"issues":[],"errors":[]}`,
 		},
+		{
+			Name:   "suppressed issues",
+			Issues: tflint.Issues{},
+			Suppressed: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 20},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 23},
+					},
+					Callers: []hcl.Range{},
+					Suppression: &tflint.Suppression{
+						Annotation: &tflint.Annotation{Content: "test_rule", Reason: "legacy resource"},
+					},
+				},
+			},
+			Stdout: `{"issues":[],"suppressed_issues":[{"rule":{"name":"test_rule","severity":"error","link":"https://github.com"},"message":"test","range":{"filename":"test.tf","start":{"line":2,"column":1},"end":{"line":2,"column":4}},"callers":[],"suppressions":[{"kind":"inSource","justification":"legacy resource"}]}],"errors":[]}`,
+		},
 		{
 			Name:   "error",
 			Error:  fmt.Errorf("Failed to work; %w", errors.New("I don't feel like working")),
@@ -51,7 +72,7 @@ func Test_jsonPrint(t *testing.T) {
 	for _, tc := range cases {
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
-		formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "json"}
+		formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "json", SuppressedIssues: tc.Suppressed}
 
 		formatter.Print(tc.Issues, tc.Error, map[string][]byte{})
 
@@ -1,11 +1,13 @@
 package formatter
 
 import (
+	"crypto/sha1"
 	"errors"
 	"fmt"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/owenrumney/go-sarif/sarif"
+	"github.com/terraform-linters/tflint/docs"
 	"github.com/terraform-linters/tflint/tflint"
 )
 
@@ -19,43 +21,11 @@ func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error) {
 	report.AddRun(run)
 
 	for _, issue := range issues {
-		rule := run.AddRule(issue.Rule.Name()).WithHelpURI(issue.Rule.Link()).WithDescription("")
-
-		var level string
-		switch issue.Rule.Severity() {
-		case tflint.ERROR:
-			level = "error"
-		case tflint.NOTICE:
-			level = "note"
-		case tflint.WARNING:
-			level = "warning"
-		default:
-			panic(fmt.Errorf("Unexpected lint type: %s", issue.Rule.Severity()))
-		}
-
-		endLine := issue.Range.End.Line
-		if endLine == 0 {
-			endLine = 1
-		}
-		endColumn := issue.Range.End.Column
-		if endColumn == 0 {
-			endColumn = 1
-		}
-
-		location := sarif.NewPhysicalLocation().
-			WithArtifactLocation(sarif.NewSimpleArtifactLocation(issue.Range.Filename)).
-			WithRegion(
-				sarif.NewRegion().
-					WithStartLine(issue.Range.Start.Line).
-					WithStartColumn(issue.Range.Start.Column).
-					WithEndLine(endLine).
-					WithEndColumn(endColumn),
-			)
-
-		run.AddResult(rule.ID).
-			WithLevel(level).
-			WithLocation(sarif.NewLocationWithPhysicalLocation(location)).
-			WithMessage(sarif.NewTextMessage(issue.Message))
+		addSarifResult(run, issue)
+	}
+	for _, issue := range f.SuppressedIssues {
+		result := addSarifResult(run, issue)
+		result.WithSuppression(newSarifSuppression(issue.Suppression))
 	}
 
 	errRun := sarif.NewRun("tflint-errors", "https://github.com/terraform-linters/tflint")
@@ -93,3 +63,109 @@ func (f *Formatter) sarifPrint(issues tflint.Issues, appErr error) {
 		panic(stdoutErr)
 	}
 }
+
+func addSarifResult(run *sarif.Run, issue *tflint.Issue) *sarif.Result {
+	level := toSarifLevel(issue.Rule.Severity())
+
+	// Core rules are described by the first paragraph of the embedded documentation
+	description := issue.Rule.Name()
+	if summary, exists := docs.RuleSummary(issue.Rule.Name()); exists && summary != "" {
+		description = summary
+	}
+	rule := run.AddRule(issue.Rule.Name()).WithHelpURI(issue.Rule.Link()).WithDescription(description)
+	// The default configuration is the severity of the rule implementation, while results have the overridden severity
+	rule.DefaultConfiguration = &sarif.ReportingConfiguration{Level: toSarifLevel(tflint.DefaultSeverity(issue.Rule))}
+
+	result := run.AddResult(rule.ID).
+		WithLevel(level).
+		WithLocation(sarif.NewLocationWithPhysicalLocation(sarifPhysicalLocation(issue.Range))).
+		WithMessage(sarif.NewTextMessage(issue.Message))
+
+	// Issues in modules have the module calls as related locations
+	for i, caller := range issue.Callers {
+		result.WithRelatedLocation(
+			sarif.NewLocationWithPhysicalLocation(sarifPhysicalLocation(caller)).
+				WithId(i).
+				WithMessage(sarif.NewTextMessage("Module call")),
+		)
+	}
+
+	if len(issue.Edits) > 0 {
+		result.WithFix(newSarifFix(issue.Edits))
+	}
+
+	return result
+}
+
+func toSarifLevel(severity tflint.Severity) string {
+	switch severity {
+	case tflint.ERROR:
+		return "error"
+	case tflint.NOTICE:
+		return "note"
+	case tflint.WARNING:
+		return "warning"
+	default:
+		panic(fmt.Errorf("Unexpected lint type: %s", severity))
+	}
+}
+
+func sarifPhysicalLocation(rng hcl.Range) *sarif.PhysicalLocation {
+	return sarif.NewPhysicalLocation().
+		WithArtifactLocation(sarif.NewSimpleArtifactLocation(rng.Filename)).
+		WithRegion(sarifRegion(rng))
+}
+
+func sarifRegion(rng hcl.Range) *sarif.Region {
+	endLine := rng.End.Line
+	if endLine == 0 {
+		endLine = 1
+	}
+	endColumn := rng.End.Column
+	if endColumn == 0 {
+		endColumn = 1
+	}
+
+	return sarif.NewRegion().
+		WithStartLine(rng.Start.Line).
+		WithStartColumn(rng.Start.Column).
+		WithEndLine(endLine).
+		WithEndColumn(endColumn)
+}
+
+// newSarifFix converts the text edits of an issue to a SARIF fix.
+// Edits are grouped by file in the order of appearance.
+func newSarifFix(edits []tflint.TextEdit) *sarif.Fix {
+	fix := sarif.NewFix()
+	changes := map[string]*sarif.ArtifactChange{}
+	for _, edit := range edits {
+		change, exists := changes[edit.Range.Filename]
+		if !exists {
+			change = sarif.NewArtifactChange(sarif.NewSimpleArtifactLocation(edit.Range.Filename))
+			changes[edit.Range.Filename] = change
+			fix.WithArtifactChange(change)
+		}
+		change.WithReplacement(
+			sarif.NewReplacement(sarifRegion(edit.Range)).
+				WithInsertedContent(sarif.NewArtifactContent().WithText(string(edit.NewText))),
+		)
+	}
+	return fix
+}
+
+// newSarifSuppression converts the passed suppression to a SARIF in-source suppression.
+// The library emits null for unset properties, which is not allowed by the schema,
+// so all properties are set. The GUID is derived from the annotation position to be stable between runs.
+func newSarifSuppression(suppression *tflint.Suppression) *sarif.Suppression {
+	token := suppression.Annotation.Token
+	location := sarifPhysicalLocation(token.Range)
+
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s:%d:%d", token.Range.Filename, token.Range.Start.Line, token.Range.Start.Column)))
+	guid := fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+
+	return sarif.NewSuppression("inSource").
+		WithStatus("accepted").
+		WithLocation(sarif.NewLocationWithPhysicalLocation(location)).
+		WithGuid(guid).
+		WithJustifcation(suppression.Justification())
+}
@@ -2,21 +2,24 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
 
 	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint/tflint"
 	"github.com/xeipuuv/gojsonschema"
 )
 
 func Test_sarifPrint(t *testing.T) {
 	cases := []struct {
-		Name   string
-		Issues tflint.Issues
-		Error  error
-		Stdout string
+		Name       string
+		Issues     tflint.Issues
+		Suppressed tflint.Issues
+		Error      error
+		Stdout     string
 	}{
 		{
 			Name:   "no issues",
@@ -72,7 +75,10 @@ func Test_sarifPrint(t *testing.T) {
             {
               "id": "test_rule",
               "shortDescription": {
-                "text": ""
+                "text": "test_rule"
+              },
+              "defaultConfiguration": {
+                "level": "error"
               },
               "helpUri": "https://github.com"
             }
@@ -114,6 +120,247 @@ func Test_sarifPrint(t *testing.T) {
       "results": []
     }
   ]
+}`,
+		},
+		{
+			Name: "issues with callers and fixes",
+			Issues: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "module/test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+					},
+					Callers: []hcl.Range{
+						{
+							Filename: "main.tf",
+							Start:    hcl.Pos{Line: 3, Column: 13, Byte: 30},
+							End:      hcl.Pos{Line: 3, Column: 18, Byte: 35},
+						},
+					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "module/test.tf",
+								Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+								End:      hcl.Pos{Line: 1, Column: 3, Byte: 2},
+							},
+							NewText: []byte("#"),
+						},
+					},
+				},
+			},
+			Stdout: `{
+  "version": "2.1.0",
+  "$schema": "https://json.schemastore.org/sarif-2.1.0-rtm.5.json",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "tflint",
+          "informationUri": "https://github.com/terraform-linters/tflint",
+          "rules": [
+            {
+              "id": "test_rule",
+              "shortDescription": {
+                "text": "test_rule"
+              },
+              "defaultConfiguration": {
+                "level": "error"
+              },
+              "helpUri": "https://github.com"
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "test_rule",
+          "level": "error",
+          "message": {
+            "text": "test"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "module/test.tf"
+                },
+                "region": {
+                  "startLine": 1,
+                  "startColumn": 1,
+                  "endLine": 1,
+                  "endColumn": 4
+                }
+              }
+            }
+          ],
+          "relatedLocations": [
+            {
+              "id": 0,
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "main.tf"
+                },
+                "region": {
+                  "startLine": 3,
+                  "startColumn": 13,
+                  "endLine": 3,
+                  "endColumn": 18
+                }
+              },
+              "message": {
+                "text": "Module call"
+              }
+            }
+          ],
+          "fixes": [
+            {
+              "artifactChanges": [
+                {
+                  "artifactLocation": {
+                    "uri": "module/test.tf"
+                  },
+                  "replacements": [
+                    {
+                      "deletedRegion": {
+                        "startLine": 1,
+                        "startColumn": 1,
+                        "endLine": 1,
+                        "endColumn": 3
+                      },
+                      "insertedContent": {
+                        "text": "#"
+                      }
+                    }
+                  ]
+                }
+              ]
+            }
+          ]
+        }
+      ]
+    },
+    {
+      "tool": {
+        "driver": {
+          "name": "tflint-errors",
+          "informationUri": "https://github.com/terraform-linters/tflint"
+        }
+      },
+      "results": []
+    }
+  ]
+}`,
+		},
+		{
+			Name:   "suppressed issues",
+			Issues: tflint.Issues{},
+			Suppressed: tflint.Issues{
+				{
+					Rule:    &testRule{},
+					Message: "test",
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 20},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 23},
+					},
+					Suppression: &tflint.Suppression{
+						Annotation: &tflint.Annotation{
+							Content: "test_rule",
+							Reason:  "legacy resource",
+							Token: hclsyntax.Token{
+								Range: hcl.Range{
+									Filename: "test.tf",
+									Start:    hcl.Pos{Line: 1, Column: 1},
+									End:      hcl.Pos{Line: 1, Column: 50},
+								},
+							},
+						},
+					},
+				},
+			},
+			Stdout: `{
+  "version": "2.1.0",
+  "$schema": "https://json.schemastore.org/sarif-2.1.0-rtm.5.json",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "tflint",
+          "informationUri": "https://github.com/terraform-linters/tflint",
+          "rules": [
+            {
+              "id": "test_rule",
+              "shortDescription": {
+                "text": "test_rule"
+              },
+              "defaultConfiguration": {
+                "level": "error"
+              },
+              "helpUri": "https://github.com"
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "test_rule",
+          "level": "error",
+          "message": {
+            "text": "test"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "test.tf"
+                },
+                "region": {
+                  "startLine": 2,
+                  "startColumn": 1,
+                  "endLine": 2,
+                  "endColumn": 4
+                }
+              }
+            }
+          ],
+          "suppressions": [
+            {
+              "kind": "inSource",
+              "status": "accepted",
+              "location": {
+                "physicalLocation": {
+                  "artifactLocation": {
+                    "uri": "test.tf"
+                  },
+                  "region": {
+                    "startLine": 1,
+                    "startColumn": 1,
+                    "endLine": 1,
+                    "endColumn": 50
+                  }
+                }
+              },
+              "guid": "8ff274fe-b1c2-03ad-24ae-00ffd25db2db",
+              "justification": "legacy resource"
+            }
+          ]
+        }
+      ]
+    },
+    {
+      "tool": {
+        "driver": {
+          "name": "tflint-errors",
+          "informationUri": "https://github.com/terraform-linters/tflint"
+        }
+      },
+      "results": []
+    }
+  ]
 }`,
 		},
 		{
@@ -143,7 +390,10 @@ func Test_sarifPrint(t *testing.T) {
             {
               "id": "test_rule",
               "shortDescription": {
-                "text": ""
+                "text": "test_rule"
+              },
+              "defaultConfiguration": {
+                "level": "error"
               },
               "helpUri": "https://github.com"
             }
@@ -268,7 +518,7 @@ func Test_sarifPrint(t *testing.T) {
 		t.Run(tc.Name, func(t *testing.T) {
 			stdout := &bytes.Buffer{}
 			stderr := &bytes.Buffer{}
-			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "sarif"}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "sarif", SuppressedIssues: tc.Suppressed}
 
 			formatter.Print(tc.Issues, tc.Error, map[string][]byte{})
 
@@ -287,3 +537,41 @@ func Test_sarifPrint(t *testing.T) {
 		})
 	}
 }
+
+func Test_sarifPrint_severityOverride(t *testing.T) {
+	config := tflint.EmptyConfig()
+	config.Rules["test_rule"] = &tflint.RuleConfig{Name: "test_rule", Enabled: true, Severity: "notice"}
+	runner := tflint.TestRunnerWithConfig(t, map[string]string{"test.tf": ""}, config)
+	runner.EmitIssue(&testRule{}, "test", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1, Column: 1}})
+
+	stdout := &bytes.Buffer{}
+	formatter := &Formatter{Stdout: stdout, Stderr: &bytes.Buffer{}, Format: "sarif"}
+	formatter.Print(runner.LookupIssues(), nil, map[string][]byte{})
+
+	var got struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						DefaultConfiguration struct {
+							Level string `json:"level"`
+						} `json:"defaultConfiguration"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				Level string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if level := got.Runs[0].Tool.Driver.Rules[0].DefaultConfiguration.Level; level != "error" {
+		t.Errorf("expected the default level is error, but got %s", level)
+	}
+	if level := got.Runs[0].Results[0].Level; level != "note" {
+		t.Errorf("expected the result level is note, but got %s", level)
+	}
+}
@@ -0,0 +1,154 @@
+package formatter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+// TemplateData is the data model passed to templates of the `template` format.
+// Issues and errors have the same structures as the JSON format.
+type TemplateData struct {
+	Issues           []JSONIssue
+	SuppressedIssues []JSONIssue
+	// Rules are the rules of the issues, without duplicates
+	Rules  []JSONRule
+	Errors []JSONError
+	// Sources are the contents of the inspected files, keyed by the file name
+	Sources map[string]string
+}
+
+// NewTemplate parses the passed text as a template for the `template` format.
+func NewTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs(map[string][]byte{})).Parse(text)
+}
+
+// templateFuncs returns helper functions available in templates.
+// Functions that need sources are bound to the passed sources.
+func templateFuncs(sources map[string][]byte) template.FuncMap {
+	sourceLines := func(filename string, start int, end int) []string {
+		src, exists := sources[filename]
+		if !exists {
+			return []string{}
+		}
+		lines := strings.Split(string(src), "\n")
+		if start < 1 {
+			start = 1
+		}
+		if end > len(lines) {
+			end = len(lines)
+		}
+		if start > end {
+			return []string{}
+		}
+		return lines[start-1 : end]
+	}
+
+	return template.FuncMap{
+		// severityAtLeast reports whether the severity is equal to or higher than the minimum
+		"severityAtLeast": func(minimum string, severity string) (bool, error) {
+			minSeverity, err := tflint.ParseSeverity(fromJSONSeverity(minimum))
+			if err != nil {
+				return false, err
+			}
+			sev, err := tflint.ParseSeverity(fromJSONSeverity(severity))
+			if err != nil {
+				return false, err
+			}
+			// Higher severities have smaller values
+			return sev <= minSeverity, nil
+		},
+		// relPath returns the path relative to the current directory
+		"relPath": func(path string) string {
+			wd, err := os.Getwd()
+			if err != nil {
+				return path
+			}
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return path
+			}
+			rel, err := filepath.Rel(wd, abs)
+			if err != nil {
+				return path
+			}
+			return filepath.ToSlash(rel)
+		},
+		// sourceLine returns the line of the file
+		"sourceLine": func(filename string, line int) string {
+			lines := sourceLines(filename, line, line)
+			if len(lines) == 0 {
+				return ""
+			}
+			return lines[0]
+		},
+		// sourceLines returns the lines of the file in the range
+		"sourceLines": func(rng JSONRange) []string {
+			return sourceLines(rng.Filename, rng.Start.Line, rng.End.Line)
+		},
+		// json returns the value encoded as JSON
+		"json": func(v interface{}) (string, error) {
+			out, err := json.Marshal(v)
+			return string(out), err
+		},
+	}
+}
+
+func (f *Formatter) templatePrint(issues tflint.Issues, appErr error, sources map[string][]byte) {
+	if f.Template == nil {
+		// The caller should have set the template. Print in the default format as a fallback.
+		if appErr == nil {
+			appErr = errors.New("The template format requires --template-file")
+		}
+		f.prettyPrint(issues, appErr, sources)
+		return
+	}
+
+	data := &TemplateData{
+		Issues:           make([]JSONIssue, len(issues)),
+		SuppressedIssues: make([]JSONIssue, len(f.SuppressedIssues)),
+		Rules:            []JSONRule{},
+		Errors:           []JSONError{},
+		Sources:          map[string]string{},
+	}
+	rules := map[string]bool{}
+	for idx, issue := range issues.Sort() {
+		data.Issues[idx] = toJSONIssue(issue)
+		if !rules[issue.Rule.Name()] {
+			rules[issue.Rule.Name()] = true
+			data.Rules = append(data.Rules, data.Issues[idx].Rule)
+		}
+	}
+	for idx, issue := range f.SuppressedIssues.Sort() {
+		data.SuppressedIssues[idx] = toJSONIssue(issue)
+	}
+	if appErr != nil {
+		data.Errors = toJSONErrors(appErr)
+	}
+	for name, src := range sources {
+		data.Sources[name] = string(src)
+	}
+
+	tmpl, err := f.Template.Clone()
+	if err != nil {
+		fmt.Fprintf(f.Stderr, "Failed to render the template; %s\n", err)
+		return
+	}
+	if err := tmpl.Funcs(templateFuncs(sources)).Execute(f.Stdout, data); err != nil {
+		fmt.Fprintf(f.Stderr, "Failed to render the template; %s\n", err)
+	}
+}
+
+// fromJSONSeverity converts severities in the JSON format to TFLint severities
+func fromJSONSeverity(severity string) string {
+	if severity == "info" {
+		return "notice"
+	}
+	return severity
+}
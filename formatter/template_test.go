@@ -0,0 +1,103 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/tflint"
+)
+
+func Test_templatePrint(t *testing.T) {
+	issues := tflint.Issues{
+		{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+				End:      hcl.Pos{Line: 2, Column: 6, Byte: 13},
+			},
+		},
+	}
+	sources := map[string][]byte{"test.tf": []byte("locals {\n  foo = 1\n}\n")}
+
+	cases := []struct {
+		Name     string
+		Template string
+		Issues   tflint.Issues
+		Error    error
+		Stdout   string
+	}{
+		{
+			Name:     "issues",
+			Template: `{{ range .Issues }}{{ .Range.Filename }}:{{ .Range.Start.Line }}: {{ .Message }} ({{ .Rule.Name }}){{ end }}`,
+			Issues:   issues,
+			Stdout:   "test.tf:2: test (test_rule)",
+		},
+		{
+			Name:     "rules",
+			Template: `{{ range .Rules }}{{ .Name }} {{ .Link }}{{ end }}`,
+			Issues:   append(issues, issues...),
+			Stdout:   "test_rule https://github.com",
+		},
+		{
+			Name:     "severityAtLeast",
+			Template: `{{ range .Issues }}{{ severityAtLeast "warning" .Rule.Severity }} {{ severityAtLeast "error" "info" }}{{ end }}`,
+			Issues:   issues,
+			Stdout:   "true false",
+		},
+		{
+			Name:     "relPath",
+			Template: `{{ relPath "./modules/../test.tf" }}`,
+			Issues:   tflint.Issues{},
+			Stdout:   "test.tf",
+		},
+		{
+			Name:     "source lines",
+			Template: `{{ range .Issues }}[{{ sourceLine .Range.Filename .Range.Start.Line }}]{{ range sourceLines .Range }}[{{ . }}]{{ end }}{{ end }}`,
+			Issues:   issues,
+			Stdout:   "[  foo = 1][  foo = 1]",
+		},
+		{
+			Name:     "json",
+			Template: `{{ range .Issues }}{{ json .Range.Start }}{{ end }}`,
+			Issues:   issues,
+			Stdout:   `{"line":2,"column":3}`,
+		},
+		{
+			Name:     "errors",
+			Template: `{{ range .Errors }}{{ .Severity }}: {{ .Message }}{{ end }}`,
+			Issues:   tflint.Issues{},
+			Error:    errors.New("I don't feel like working"),
+			Stdout:   "error: I don't feel like working",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tmpl, err := NewTemplate("test", tc.Template)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			formatter := &Formatter{Stdout: stdout, Stderr: stderr, Format: "template", Template: tmpl}
+
+			formatter.Print(tc.Issues, tc.Error, sources)
+
+			if stdout.String() != tc.Stdout {
+				t.Fatalf("expected=%s, stdout=%s, stderr=%s", tc.Stdout, stdout.String(), stderr.String())
+			}
+		})
+	}
+}
+
+func Test_NewTemplate_parseError(t *testing.T) {
+	_, err := NewTemplate("test", `{{ range .Issues }}`)
+	if err == nil {
+		t.Fatal("Expected error is not occurred")
+	}
+}
@@ -195,7 +195,9 @@ func (h *handler) inspect() (map[string][]lsp.Diagnostic, error) {
 				return ret, fmt.Errorf("Failed to parse `%s` plugin config", name)
 			}
 		}
-		err = ruleset.ApplyConfig(content, h.config.Sources())
+		// Plugins cannot evaluate functions like env(), so they are evaluated in advance
+		content, sources := tflint.EvaluateConfigContent(content, h.config.Sources())
+		err = ruleset.ApplyConfig(content, sources)
 		if err != nil {
 			return ret, fmt.Errorf("Failed to apply config to `%s` plugin", name)
 		}
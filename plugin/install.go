@@ -55,6 +55,17 @@ func (c *InstallConfig) AssetName() string {
 	return fmt.Sprintf("tflint-ruleset-%s_%s_%s.zip", c.Name, runtime.GOOS, runtime.GOARCH)
 }
 
+// RuleDocLink returns a link to the documentation of the rule in the GitHub repository.
+// The repository must contain the documentation as `docs/rules/{rule}.md` at the release tag,
+// which is the layout of rulesets created from the template.
+// Returns an empty string for manually installed plugins because the repository is unknown.
+func (c *InstallConfig) RuleDocLink(rule string) string {
+	if c.ManuallyInstalled() {
+		return ""
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/blob/%s/docs/rules/%s.md", c.SourceOwner, c.SourceRepo, c.TagName(), rule)
+}
+
 // Install fetches the release from GitHub and puts the binary in the plugin directory.
 // This installation process will automatically check the checksum of the downloaded zip file.
 // Therefore, the release must always contain a checksum file.
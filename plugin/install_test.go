@@ -40,3 +40,38 @@ func Test_Install(t *testing.T) {
 		t.Fatalf("Installed binary name is invalid: expected=%s, got=%s", expected, info.Name())
 	}
 }
+
+func Test_RuleDocLink(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Config *tflint.PluginConfig
+		Want   string
+	}{
+		{
+			Name: "auto installed plugin",
+			Config: &tflint.PluginConfig{
+				Name:        "aws",
+				Enabled:     true,
+				Version:     "0.4.0",
+				Source:      "github.com/terraform-linters/tflint-ruleset-aws",
+				SourceOwner: "terraform-linters",
+				SourceRepo:  "tflint-ruleset-aws",
+			},
+			Want: "https://github.com/terraform-linters/tflint-ruleset-aws/blob/v0.4.0/docs/rules/aws_instance_invalid_type.md",
+		},
+		{
+			Name:   "manually installed plugin",
+			Config: &tflint.PluginConfig{Name: "aws", Enabled: true},
+			Want:   "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := NewInstallConfig(tflint.EmptyConfig(), test.Config).RuleDocLink("aws_instance_invalid_type")
+			if got != test.Want {
+				t.Errorf("expected %s, but got %s", test.Want, got)
+			}
+		})
+	}
+}
@@ -69,7 +69,8 @@ func (s *GRPCServer) GetRuleConfigContent(name string, bodyS *hclext.BodySchema)
 	if diags.HasErrors() {
 		return body, s.runner.ConfigSources(), diags
 	}
-	return body, s.runner.ConfigSources(), nil
+	body, sources := tflint.EvaluateConfigContent(body, s.runner.ConfigSources())
+	return body, sources, nil
 }
 
 // EvaluateExpr returns the value of the passed expression.
@@ -100,8 +101,6 @@ func (s *GRPCServer) EmitIssue(rule sdk.Rule, message string, location hcl.Range
 		s.runner.EmitIssue(rule, message, location)
 		return nil
 	}
-	return s.runner.WithExpressionContext(expr, func() error {
-		s.runner.EmitIssue(rule, message, location)
-		return nil
-	})
+	s.runner.EmitIssueOnExpr(rule, message, location, expr)
+	return nil
 }
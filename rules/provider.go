@@ -12,6 +12,8 @@ import (
 type Rule interface {
 	Name() string
 	Enabled() bool
+	Severity() tflint.Severity
+	Link() string
 	Check(runner *tflint.Runner) error
 }
 
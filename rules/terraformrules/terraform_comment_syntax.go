@@ -71,10 +71,18 @@ func (r *TerraformCommentSyntaxRule) checkComments(runner *tflint.Runner, filena
 		}
 
 		if strings.HasPrefix(string(token.Bytes), "//") {
-			runner.EmitIssue(
+			// Replace only the leading `//` so that the comment body is preserved
+			start := token.Range.Start
+			end := hcl.Pos{Line: start.Line, Column: start.Column + 2, Byte: start.Byte + 2}
+
+			runner.EmitIssueWithFix(
 				r,
 				"Single line comments should begin with #",
 				token.Range,
+				tflint.TextEdit{
+					Range:   hcl.Range{Filename: filename, Start: start, End: end},
+					NewText: []byte("#"),
+				},
 			)
 		}
 	}
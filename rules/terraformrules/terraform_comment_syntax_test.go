@@ -46,6 +46,16 @@ func Test_TerraformCommentSyntaxRule(t *testing.T) {
 							Column: 7,
 						},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "variables.tf",
+								Start:    hcl.Pos{Line: 1, Column: 1},
+								End:      hcl.Pos{Line: 1, Column: 3},
+							},
+							NewText: []byte("#"),
+						},
+					},
 				},
 			},
 		},
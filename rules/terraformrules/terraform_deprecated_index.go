@@ -1,6 +1,7 @@
 package terraformrules
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/hashicorp/hcl/v2"
@@ -58,10 +59,15 @@ func (r *TerraformDeprecatedIndexRule) Check(runner *tflint.Runner) error {
 					}
 
 					if tokens[0].Type == hclsyntax.TokenDot {
-						runner.EmitIssue(
+						// foo.0 can be fixed by replacing `.0` with `[0]`
+						runner.EmitIssueWithFix(
 							r,
 							"List items should be accessed using square brackets",
 							expr.Range(),
+							tflint.TextEdit{
+								Range:   traversal.SrcRange,
+								NewText: []byte(fmt.Sprintf("[%s]", bytes[len(tokens[0].Bytes):])),
+							},
 						)
 					}
 				}
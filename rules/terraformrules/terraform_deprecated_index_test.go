@@ -36,6 +36,16 @@ locals {
 							Column: 17,
 						},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "config.tf",
+								Start:    hcl.Pos{Line: 4, Column: 15},
+								End:      hcl.Pos{Line: 4, Column: 17},
+							},
+							NewText: []byte("[0]"),
+						},
+					},
 				},
 			},
 		},
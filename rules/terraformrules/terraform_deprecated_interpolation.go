@@ -54,13 +54,35 @@ func (r *TerraformDeprecatedInterpolationRule) Check(runner *tflint.Runner) erro
 }
 
 func (r *TerraformDeprecatedInterpolationRule) checkForDeprecatedInterpolationsInExpr(runner *tflint.Runner, expr hcl.Expression) {
-	if _, ok := expr.(*hclsyntax.TemplateWrapExpr); !ok {
+	wrapExpr, ok := expr.(*hclsyntax.TemplateWrapExpr)
+	if !ok {
 		return
 	}
 
-	runner.EmitIssue(
+	message := "Interpolation-only expressions are deprecated in Terraform v0.12.14"
+	wrapped := wrapExpr.Wrapped.Range()
+	file := runner.File(wrapped.Filename)
+	if file == nil {
+		runner.EmitIssue(r, message, expr.Range())
+		return
+	}
+
+	// "${var.foo}" can be fixed by unwrapping to var.foo.
+	// The template can be nested in a larger expression, so operators are wrapped in parentheses
+	// to keep the precedence, e.g. "${var.a ? "b" : "c"}" == "b" is fixed to (var.a ? "b" : "c") == "b".
+	newText := wrapped.SliceBytes(file.Bytes)
+	switch wrapExpr.Wrapped.(type) {
+	case *hclsyntax.ConditionalExpr, *hclsyntax.BinaryOpExpr, *hclsyntax.UnaryOpExpr:
+		newText = append(append([]byte("("), newText...), ')')
+	}
+
+	runner.EmitIssueWithFix(
 		r,
-		"Interpolation-only expressions are deprecated in Terraform v0.12.14",
+		message,
 		expr.Range(),
+		tflint.TextEdit{
+			Range:   expr.Range(),
+			NewText: newText,
+		},
 	)
 }
@@ -28,6 +28,16 @@ resource "null_resource" "a" {
 						Start:    hcl.Pos{Line: 3, Column: 13},
 						End:      hcl.Pos{Line: 3, Column: 30},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "config.tf",
+								Start:    hcl.Pos{Line: 3, Column: 13},
+								End:      hcl.Pos{Line: 3, Column: 30},
+							},
+							NewText: []byte(`var.triggers`),
+						},
+					},
 				},
 			},
 		},
@@ -46,6 +56,16 @@ provider "null" {
 						Start:    hcl.Pos{Line: 3, Column: 8},
 						End:      hcl.Pos{Line: 3, Column: 32},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "config.tf",
+								Start:    hcl.Pos{Line: 3, Column: 8},
+								End:      hcl.Pos{Line: 3, Column: 32},
+							},
+							NewText: []byte(`var.triggers["foo"]`),
+						},
+					},
 				},
 			},
 		},
@@ -64,6 +84,16 @@ locals {
 						Start:    hcl.Pos{Line: 3, Column: 8},
 						End:      hcl.Pos{Line: 3, Column: 32},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "config.tf",
+								Start:    hcl.Pos{Line: 3, Column: 8},
+								End:      hcl.Pos{Line: 3, Column: 32},
+							},
+							NewText: []byte(`var.triggers["foo"]`),
+						},
+					},
 				},
 			},
 		},
@@ -84,6 +114,16 @@ resource "null_resource" "a" {
 						Start:    hcl.Pos{Line: 4, Column: 12},
 						End:      hcl.Pos{Line: 4, Column: 41},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "config.tf",
+								Start:    hcl.Pos{Line: 4, Column: 12},
+								End:      hcl.Pos{Line: 4, Column: 41},
+							},
+							NewText: []byte(`var.triggers["greeting"]`),
+						},
+					},
 				},
 			},
 		},
@@ -110,6 +150,44 @@ resource "null_resource" "a" {
 						Start:    hcl.Pos{Line: 3, Column: 14},
 						End:      hcl.Pos{Line: 3, Column: 31},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "config.tf",
+								Start:    hcl.Pos{Line: 3, Column: 14},
+								End:      hcl.Pos{Line: 3, Column: 31},
+							},
+							NewText: []byte(`var.triggers`),
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "interpolation with operators in a larger expression",
+			Content: `
+locals {
+	x = "${var.a ? "b" : "c"}" == "b"
+}`,
+			Expected: tflint.Issues{
+				{
+					Rule:    NewTerraformDeprecatedInterpolationRule(),
+					Message: "Interpolation-only expressions are deprecated in Terraform v0.12.14",
+					Range: hcl.Range{
+						Filename: "config.tf",
+						Start:    hcl.Pos{Line: 3, Column: 6},
+						End:      hcl.Pos{Line: 3, Column: 28},
+					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "config.tf",
+								Start:    hcl.Pos{Line: 3, Column: 6},
+								End:      hcl.Pos{Line: 3, Column: 28},
+							},
+							NewText: []byte(`(var.a ? "b" : "c")`),
+						},
+					},
 				},
 			},
 		},
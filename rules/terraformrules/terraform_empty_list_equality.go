@@ -1,6 +1,7 @@
 package terraformrules
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/hashicorp/hcl/v2"
@@ -58,10 +59,10 @@ func (r *TerraformEmptyListEqualityRule) checkEmptyList(runner *tflint.Runner) e
 			if binaryOpExpr, ok := conditionalExpr.Condition.(*hclsyntax.BinaryOpExpr); ok {
 				if binaryOpExpr.Op.Type.FriendlyName() == "bool" {
 					if right, ok := binaryOpExpr.RHS.(*hclsyntax.TupleConsExpr); ok {
-						checkEmptyList(right, runner, r, binaryOpExpr)
+						checkEmptyList(right, binaryOpExpr.LHS, runner, r, binaryOpExpr)
 					}
 					if left, ok := binaryOpExpr.LHS.(*hclsyntax.TupleConsExpr); ok {
-						checkEmptyList(left, runner, r, binaryOpExpr)
+						checkEmptyList(left, binaryOpExpr.RHS, runner, r, binaryOpExpr)
 					}
 				}
 			}
@@ -70,12 +71,40 @@ func (r *TerraformEmptyListEqualityRule) checkEmptyList(runner *tflint.Runner) e
 	})
 }
 
-func checkEmptyList(tupleConsExpr *hclsyntax.TupleConsExpr, runner *tflint.Runner, r *TerraformEmptyListEqualityRule, binaryOpExpr *hclsyntax.BinaryOpExpr) {
+func checkEmptyList(tupleConsExpr *hclsyntax.TupleConsExpr, other hclsyntax.Expression, runner *tflint.Runner, r *TerraformEmptyListEqualityRule, binaryOpExpr *hclsyntax.BinaryOpExpr) {
 	if len(tupleConsExpr.Exprs) == 0 {
-		runner.EmitIssue(
+		runner.EmitIssueWithFix(
 			r,
 			"Comparing a collection with an empty list is invalid. To detect an empty collection, check its length.",
 			binaryOpExpr.Range(),
+			emptyListEqualityFix(other, runner, binaryOpExpr)...,
 		)
 	}
 }
+
+// emptyListEqualityFix returns an edit that replaces `x == []` with `length(x) == 0`.
+// Only `==` and `!=` can be fixed mechanically.
+func emptyListEqualityFix(other hclsyntax.Expression, runner *tflint.Runner, binaryOpExpr *hclsyntax.BinaryOpExpr) []tflint.TextEdit {
+	var op string
+	switch binaryOpExpr.Op {
+	case hclsyntax.OpEqual:
+		op = "=="
+	case hclsyntax.OpNotEqual:
+		op = "!="
+	default:
+		return nil
+	}
+
+	otherRange := other.Range()
+	file := runner.File(otherRange.Filename)
+	if file == nil {
+		return nil
+	}
+
+	return []tflint.TextEdit{
+		{
+			Range:   binaryOpExpr.Range(),
+			NewText: []byte(fmt.Sprintf("length(%s) %s 0", otherRange.SliceBytes(file.Bytes), op)),
+		},
+	}
+}
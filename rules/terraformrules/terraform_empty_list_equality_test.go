@@ -29,6 +29,16 @@ resource "aws_db_instance" "mysql" {
 						Start:    hcl.Pos{Line: 3, Column: 10},
 						End:      hcl.Pos{Line: 3, Column: 18},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "resource.tf",
+								Start:    hcl.Pos{Line: 3, Column: 10},
+								End:      hcl.Pos{Line: 3, Column: 18},
+							},
+							NewText: []byte("length([]) == 0"),
+						},
+					},
 				},
 				{
 					Rule:    NewTerraformEmptyListEqualityRule(),
@@ -38,6 +48,16 @@ resource "aws_db_instance" "mysql" {
 						Start:    hcl.Pos{Line: 3, Column: 10},
 						End:      hcl.Pos{Line: 3, Column: 18},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "resource.tf",
+								Start:    hcl.Pos{Line: 3, Column: 10},
+								End:      hcl.Pos{Line: 3, Column: 18},
+							},
+							NewText: []byte("length([]) == 0"),
+						},
+					},
 				},
 			},
 		},
@@ -60,6 +80,16 @@ resource "aws_db_instance" "mysql" {
 						Start:    hcl.Pos{Line: 6, Column: 10},
 						End:      hcl.Pos{Line: 6, Column: 27},
 					},
+					Edits: []tflint.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "resource.tf",
+								Start:    hcl.Pos{Line: 6, Column: 10},
+								End:      hcl.Pos{Line: 6, Column: 27},
+							},
+							NewText: []byte("length(var.my_list) != 0"),
+						},
+					},
 				},
 			},
 		},
@@ -17,6 +17,8 @@ import (
 type Parser struct {
 	fs afero.Afero
 	p  *hclparse.Parser
+
+	exclude func(path string) bool
 }
 
 // NewParser creates and returns a new Parser that reads files from the given
@@ -33,6 +35,18 @@ func NewParser(fs afero.Fs) *Parser {
 	}
 }
 
+// SetExcludeFunc sets the function to exclude configuration files when
+// reading directories. Excluded files are ignored as if they do not exist.
+func (p *Parser) SetExcludeFunc(fn func(path string) bool) {
+	p.exclude = fn
+}
+
+// ClearCache discards all files that have been loaded through this parser,
+// so that they are read from disk again when they are loaded the next time.
+func (p *Parser) ClearCache() {
+	p.p = hclparse.NewParser()
+}
+
 // LoadHCLFile is a low-level method that reads the file at the given path,
 // parses it, and returns the hcl.Body representing its root. In many cases
 // it is better to use one of the other Load*File methods on this type,
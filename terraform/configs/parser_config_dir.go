@@ -112,6 +112,9 @@ func (p *Parser) dirFiles(dir string) (primary, override []string, diags hcl.Dia
 		isOverride := baseName == "override" || strings.HasSuffix(baseName, "_override")
 
 		fullPath := filepath.Join(dir, name)
+		if p.exclude != nil && p.exclude(fullPath) {
+			continue
+		}
 		if isOverride {
 			override = append(override, fullPath)
 		} else {
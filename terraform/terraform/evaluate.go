@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/agext/levenshtein"
@@ -68,6 +69,10 @@ type evaluationStateData struct {
 	// since the user specifies in that case which variable name to locally
 	// shadow.)
 	InstanceKeyData InstanceKeyEvalData
+
+	// evaluatingLocals is the stack of local values currently being
+	// evaluated. It is used to detect self-referencing local values.
+	evaluatingLocals []addrs.LocalValue
 }
 
 // InstanceKeyEvalData is the old name for instances.RepetitionData, aliased
@@ -157,6 +162,87 @@ func (d *evaluationStateData) GetInputVariable(addr addrs.InputVariable, rng tfd
 	return val, diags
 }
 
+func (d *evaluationStateData) GetLocalValue(addr addrs.LocalValue, rng tfdiags.SourceRange) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	// First we'll make sure the requested value is declared in configuration,
+	// so we can produce a nice message if not.
+	moduleConfig := d.Evaluator.Config.DescendentForInstance(d.ModulePath)
+	if moduleConfig == nil {
+		// should never happen, since we can't be evaluating in a module
+		// that wasn't mentioned in configuration.
+		panic(fmt.Sprintf("local value read from %s, which has no configuration", d.ModulePath))
+	}
+
+	config := moduleConfig.Module.Locals[addr.Name]
+	if config == nil {
+		var suggestions []string
+		for k := range moduleConfig.Module.Locals {
+			suggestions = append(suggestions, k)
+		}
+		suggestion := nameSuggestion(addr.Name, suggestions)
+		if suggestion != "" {
+			suggestion = fmt.Sprintf(" Did you mean %q?", suggestion)
+		}
+
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  `Reference to undeclared local value`,
+			Detail:   fmt.Sprintf(`A local value with the name %q has not been declared.%s`, addr.Name, suggestion),
+			Subject:  rng.ToHCL().Ptr(),
+		})
+		return cty.DynamicVal, diags
+	}
+
+	// Unlike Terraform, there is no state to look up the final value of the local,
+	// so its expression is evaluated on demand. Overrides are already merged into
+	// the module, so the expression here is always the effective one.
+	for i, evaluating := range d.evaluatingLocals {
+		if evaluating.Name != addr.Name {
+			continue
+		}
+		chain := []string{}
+		for _, local := range d.evaluatingLocals[i:] {
+			chain = append(chain, local.String())
+		}
+		chain = append(chain, addr.String())
+
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  `Circular reference in local values`,
+			Detail:   fmt.Sprintf(`The local value %q refers to itself: %s.`, addr.Name, strings.Join(chain, " -> ")),
+			Subject:  rng.ToHCL().Ptr(),
+		})
+		return cty.DynamicVal, diags
+	}
+
+	refs, refDiags := lang.ReferencesInExpr(config.Expr)
+	diags = diags.Append(refDiags)
+	if refDiags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+	for _, ref := range refs {
+		switch ref.Subject.(type) {
+		case addrs.InputVariable, addrs.LocalValue, addrs.PathAttr, addrs.TerraformAttr:
+			// These references can be resolved statically.
+		default:
+			// Local values that depend on resources, modules, etc. cannot be determined.
+			return cty.DynamicVal, diags
+		}
+	}
+
+	d.evaluatingLocals = append(d.evaluatingLocals, addr)
+	defer func() {
+		d.evaluatingLocals = d.evaluatingLocals[:len(d.evaluatingLocals)-1]
+	}()
+
+	scope := d.Evaluator.Scope(d, nil)
+	val, evalDiags := scope.EvalExpr(config.Expr, cty.DynamicPseudoType)
+	diags = diags.Append(evalDiags)
+
+	return val, diags
+}
+
 func (d *evaluationStateData) GetPathAttr(addr addrs.PathAttr, rng tfdiags.SourceRange) (cty.Value, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 	switch addr.Name {
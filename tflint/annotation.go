@@ -2,17 +2,56 @@ package tflint
 
 import (
 	"fmt"
+	"log"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
-var annotationPattern = regexp.MustCompile(`tflint-ignore: (\S+)`)
+// Annotations are followed by a comma-separated list of rule names, an optional expiration date,
+// and an optional reason after "--". e.g. `tflint-ignore: rule1, rule2 expires=2026-12-31 -- reason`
+// The arguments are parsed by parseAnnotationArgs.
+const annotationArgsPattern = `([^\s,]+.*)`
+
+// The list continues only for rule names delimited by a comma, the expiration date, the reason or the end,
+// so it stops at the first item that is not a rule name, like `see ticket 123` in `rule1, see ticket 123`.
+var annotationNextRulePattern = regexp.MustCompile(`^\s*,\s*([a-z0-9_]+)(?:\s*,|\s+expires=|\s+--|\s*(?:\*/)?\s*$)`)
+var annotationExpiresPattern = regexp.MustCompile(`^\s+expires=(\d{4}-\d{2}-\d{2})`)
+var annotationReasonPattern = regexp.MustCompile(`^\s+--\s*(.*)`)
+
+var annotationPattern = regexp.MustCompile(`tflint-ignore: ` + annotationArgsPattern)
+var fileAnnotationPattern = regexp.MustCompile(`tflint-ignore-file: ` + annotationArgsPattern)
+var beginAnnotationPattern = regexp.MustCompile(`tflint-ignore-begin: ` + annotationArgsPattern)
+var endAnnotationPattern = regexp.MustCompile(`tflint-ignore-end\b`)
+
+// AnnotationType represents the kind of annotations
+type AnnotationType int32
+
+const (
+	// LineAnnotation ignores issues on the same line or the line below it (`tflint-ignore`).
+	// If a block starts on the line below it, the entire block is ignored.
+	LineAnnotation AnnotationType = iota
+	// FileAnnotation ignores issues in the entire file (`tflint-ignore-file`)
+	FileAnnotation
+	// RangeAnnotation ignores issues between `tflint-ignore-begin` and `tflint-ignore-end`
+	RangeAnnotation
+)
 
 // Annotation represents comments with special meaning in TFLint
 type Annotation struct {
+	// Content is a comma-separated list of rule names
 	Content string
 	Token   hclsyntax.Token
+	Type    AnnotationType
+	// EndLine is the last line affected by the annotation.
+	// It is set for range annotations and line annotations followed by a block.
+	EndLine int
+	// Reason is the justification written after "--"
+	Reason string
+	// Expires is the date after which the annotation is expired. Zero means no expiration.
+	Expires time.Time
 }
 
 // Annotations is slice of Annotation
@@ -21,42 +60,251 @@ type Annotations []Annotation
 // NewAnnotations find annotations from the passed tokens and return that list.
 func NewAnnotations(tokens hclsyntax.Tokens) Annotations {
 	ret := Annotations{}
+	// Indexes of `tflint-ignore-begin` annotations not closed yet
+	opened := []int{}
 
-	for _, token := range tokens {
+	for i, token := range tokens {
 		if token.Type != hclsyntax.TokenComment {
 			continue
 		}
 
+		if match := fileAnnotationPattern.FindStringSubmatch(string(token.Bytes)); match != nil {
+			ret = append(ret, newAnnotation(match, token, FileAnnotation))
+			continue
+		}
+
+		if match := beginAnnotationPattern.FindStringSubmatch(string(token.Bytes)); match != nil {
+			opened = append(opened, len(ret))
+			ret = append(ret, newAnnotation(match, token, RangeAnnotation))
+			continue
+		}
+
+		if endAnnotationPattern.MatchString(string(token.Bytes)) {
+			if len(opened) > 0 {
+				ret[opened[len(opened)-1]].EndLine = token.Range.Start.Line
+				opened = opened[:len(opened)-1]
+			}
+			continue
+		}
+
 		match := annotationPattern.FindStringSubmatch(string(token.Bytes))
-		if len(match) != 2 {
+		if match == nil {
 			continue
 		}
-		ret = append(ret, Annotation{
-			Content: match[1],
-			Token:   token,
-		})
+		annotation := newAnnotation(match, token, LineAnnotation)
+		// Only annotations on their own line can affect the block below
+		if i == 0 || tokens[i-1].Type == hclsyntax.TokenNewline || tokens[i-1].Type == hclsyntax.TokenComment {
+			annotation.EndLine = blockEndLine(tokens[i+1:])
+		}
+		ret = append(ret, annotation)
+	}
+
+	// Annotations that are not closed affect until the end of the file
+	if len(opened) > 0 && len(tokens) > 0 {
+		for _, idx := range opened {
+			ret[idx].EndLine = tokens[len(tokens)-1].Range.End.Line
+		}
 	}
 
 	return ret
 }
 
+func newAnnotation(match []string, token hclsyntax.Token, annotationType AnnotationType) Annotation {
+	content, expiresStr, reason := parseAnnotationArgs(match[1])
+	annotation := Annotation{
+		Content: content,
+		Token:   token,
+		Type:    annotationType,
+		Reason:  strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(reason), "*/")),
+	}
+	if expiresStr != "" {
+		expires, err := time.ParseInLocation("2006-01-02", expiresStr, time.Local)
+		if err != nil {
+			log.Printf("[WARN] Invalid expiration date in %s: %s", token.Range.String(), err)
+		} else {
+			annotation.Expires = expires
+		}
+	}
+	return annotation
+}
+
+// parseAnnotationArgs returns the list of rule names, the expiration date and the reason in the arguments.
+// Any text after the list that is neither the expiration date nor the reason is ignored.
+func parseAnnotationArgs(args string) (string, string, string) {
+	end := strings.IndexAny(args, " \t\r\n,")
+	if end < 0 {
+		end = len(args)
+	}
+	for {
+		loc := annotationNextRulePattern.FindStringSubmatchIndex(args[end:])
+		if loc == nil {
+			break
+		}
+		// The delimiter after the rule name is not a part of the list
+		end += loc[3]
+	}
+	content, rest := args[:end], args[end:]
+
+	expires := ""
+	if match := annotationExpiresPattern.FindStringSubmatch(rest); match != nil {
+		expires = match[1]
+		rest = rest[len(match[0]):]
+	}
+	reason := ""
+	if match := annotationReasonPattern.FindStringSubmatch(rest); match != nil {
+		reason = match[1]
+	}
+	return content, expires, reason
+}
+
+// blockEndLine returns the last line of the block starting with the passed tokens.
+// Comments and newlines before the block are skipped. If the tokens do not start
+// with a block, it returns 0.
+func blockEndLine(tokens hclsyntax.Tokens) int {
+	i := 0
+	for i < len(tokens) && (tokens[i].Type == hclsyntax.TokenNewline || tokens[i].Type == hclsyntax.TokenComment) {
+		i++
+	}
+
+	// Block header: identifier, labels, and an opening brace
+	if i >= len(tokens) || tokens[i].Type != hclsyntax.TokenIdent {
+		return 0
+	}
+	for i++; i < len(tokens) && tokens[i].Type != hclsyntax.TokenOBrace; i++ {
+		switch tokens[i].Type {
+		case hclsyntax.TokenIdent:
+		case hclsyntax.TokenOQuote:
+			for i < len(tokens) && tokens[i].Type != hclsyntax.TokenCQuote {
+				i++
+			}
+		default:
+			return 0
+		}
+	}
+
+	depth := 0
+	for ; i < len(tokens); i++ {
+		switch tokens[i].Type {
+		case hclsyntax.TokenOBrace:
+			depth++
+		case hclsyntax.TokenCBrace:
+			depth--
+			if depth == 0 {
+				return tokens[i].Range.Start.Line
+			}
+		}
+	}
+	return 0
+}
+
 // IsAffected checks if the passed issue is affected with the annotation
 func (a *Annotation) IsAffected(issue *Issue) bool {
 	if a.Token.Range.Filename != issue.Range.Filename {
 		return false
 	}
-	if a.Content == issue.Rule.Name() || a.Content == "all" {
-		if a.Token.Range.Start.Line == issue.Range.Start.Line {
+	if !a.matchRule(issue.Rule.Name()) {
+		return false
+	}
+
+	line := issue.Range.Start.Line
+	switch a.Type {
+	case FileAnnotation:
+		return true
+	case RangeAnnotation:
+		return a.Token.Range.Start.Line <= line && line <= a.EndLine
+	default:
+		if a.Token.Range.Start.Line == line {
 			return true
 		}
-		if a.Token.Range.Start.Line == issue.Range.Start.Line-1 {
+		if a.Token.Range.Start.Line == line-1 {
+			return true
+		}
+		return a.Token.Range.Start.Line < line && line <= a.EndLine
+	}
+}
+
+func (a *Annotation) matchRule(name string) bool {
+	for _, rule := range a.ruleNames() {
+		if rule == name || rule == "all" {
 			return true
 		}
 	}
 	return false
 }
 
+// IsExpired checks if the annotation is expired at the passed time.
+// The annotation is valid until the end of the expiration date.
+func (a *Annotation) IsExpired(now time.Time) bool {
+	if a.Expires.IsZero() {
+		return false
+	}
+	return !now.Before(a.Expires.AddDate(0, 0, 1))
+}
+
+// ruleNames returns the rule names listed in the annotation
+func (a *Annotation) ruleNames() []string {
+	ret := []string{}
+	for _, rule := range strings.Split(a.Content, ",") {
+		ret = append(ret, strings.TrimSpace(rule))
+	}
+	return ret
+}
+
 // String returns the string representation of the annotation
 func (a *Annotation) String() string {
 	return fmt.Sprintf("annotation:%s (%s)", a.Content, a.Token.Range.String())
 }
+
+// unusedAnnotationRule is a pseudo rule for reporting annotations that do not suppress any issues.
+type unusedAnnotationRule struct{}
+
+// Name returns the rule name
+func (r *unusedAnnotationRule) Name() string {
+	return "tflint_unused_annotation"
+}
+
+// Severity returns the rule severity
+func (r *unusedAnnotationRule) Severity() Severity {
+	return WARNING
+}
+
+// Link returns the rule reference link
+func (r *unusedAnnotationRule) Link() string {
+	return fmt.Sprintf("https://github.com/terraform-linters/tflint/blob/v%s/docs/user-guide/annotations.md", Version)
+}
+
+// annotationWithoutReasonRule is a pseudo rule for reporting annotations without a reason.
+type annotationWithoutReasonRule struct{}
+
+// Name returns the rule name
+func (r *annotationWithoutReasonRule) Name() string {
+	return "tflint_annotation_without_reason"
+}
+
+// Severity returns the rule severity
+func (r *annotationWithoutReasonRule) Severity() Severity {
+	return ERROR
+}
+
+// Link returns the rule reference link
+func (r *annotationWithoutReasonRule) Link() string {
+	return fmt.Sprintf("https://github.com/terraform-linters/tflint/blob/v%s/docs/user-guide/annotations.md", Version)
+}
+
+// expiredAnnotationRule is a pseudo rule for reporting expired annotations.
+type expiredAnnotationRule struct{}
+
+// Name returns the rule name
+func (r *expiredAnnotationRule) Name() string {
+	return "tflint_expired_annotation"
+}
+
+// Severity returns the rule severity
+func (r *expiredAnnotationRule) Severity() Severity {
+	return ERROR
+}
+
+// Link returns the rule reference link
+func (r *expiredAnnotationRule) Link() string {
+	return fmt.Sprintf("https://github.com/terraform-linters/tflint/blob/v%s/docs/user-guide/annotations.md", Version)
+}
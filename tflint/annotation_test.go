@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -78,6 +79,149 @@ func Test_NewAnnotations(t *testing.T) {
 	}
 }
 
+func Test_NewAnnotations_types(t *testing.T) {
+	src := `# tflint-ignore-file: rule1, rule2
+resource "aws_instance" "foo" {
+  # tflint-ignore-begin: rule3
+  instance_type = "t2.micro"
+  ami           = "ami-12345678"
+  # tflint-ignore-end
+}
+
+# tflint-ignore: rule4
+resource "aws_instance" "bar" {
+  tags = {
+    Name = "${var.name}"
+  }
+}
+
+resource "aws_instance" "baz" { # tflint-ignore: rule5
+}
+
+# tflint-ignore: rule6
+locals {
+  foo = "bar"
+}
+# tflint-ignore-begin: rule7
+`
+	tokens, diags := hclsyntax.LexConfig([]byte(src), "resource.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	type result struct {
+		Content string
+		Type    AnnotationType
+		Line    int
+		EndLine int
+	}
+	got := []result{}
+	for _, annotation := range NewAnnotations(tokens) {
+		got = append(got, result{
+			Content: annotation.Content,
+			Type:    annotation.Type,
+			Line:    annotation.Token.Range.Start.Line,
+			EndLine: annotation.EndLine,
+		})
+	}
+
+	expected := []result{
+		{Content: "rule1, rule2", Type: FileAnnotation, Line: 1},
+		{Content: "rule3", Type: RangeAnnotation, Line: 3, EndLine: 6},
+		{Content: "rule4", Type: LineAnnotation, Line: 9, EndLine: 14},
+		{Content: "rule5", Type: LineAnnotation, Line: 16},
+		{Content: "rule6", Type: LineAnnotation, Line: 19, EndLine: 22},
+		{Content: "rule7", Type: RangeAnnotation, Line: 23, EndLine: 24},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_NewAnnotations_reasonAndExpires(t *testing.T) {
+	src := `# tflint-ignore: rule1 -- legacy AMI, see OPS-123
+# tflint-ignore: rule2, rule3 expires=2000-01-01 -- migrating
+/* tflint-ignore-file: rule4 expires=2000-01-01 */
+# tflint-ignore: rule5
+# tflint-ignore: aws_instance_invalid_type, see ticket 123
+# tflint-ignore: rule6, rule7 This is also comment
+/* tflint-ignore: rule8, rule9 */
+`
+	tokens, diags := hclsyntax.LexConfig([]byte(src), "resource.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	type result struct {
+		Content string
+		Reason  string
+		Expires string
+	}
+	got := []result{}
+	for _, annotation := range NewAnnotations(tokens) {
+		expires := ""
+		if !annotation.Expires.IsZero() {
+			expires = annotation.Expires.Format("2006-01-02")
+		}
+		got = append(got, result{Content: annotation.Content, Reason: annotation.Reason, Expires: expires})
+	}
+
+	expected := []result{
+		{Content: "rule1", Reason: "legacy AMI, see OPS-123"},
+		{Content: "rule2, rule3", Reason: "migrating", Expires: "2000-01-01"},
+		{Content: "rule4", Expires: "2000-01-01"},
+		{Content: "rule5"},
+		{Content: "aws_instance_invalid_type"},
+		{Content: "rule6"},
+		{Content: "rule8, rule9"},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_IsExpired(t *testing.T) {
+	expires := time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local)
+
+	cases := []struct {
+		Name     string
+		Expires  time.Time
+		Now      time.Time
+		Expected bool
+	}{
+		{
+			Name:     "no expiration",
+			Now:      time.Date(2000, 1, 2, 0, 0, 0, 0, time.Local),
+			Expected: false,
+		},
+		{
+			Name:     "before the expiration date",
+			Expires:  expires,
+			Now:      time.Date(1999, 12, 31, 12, 0, 0, 0, time.Local),
+			Expected: false,
+		},
+		{
+			Name:     "on the expiration date",
+			Expires:  expires,
+			Now:      time.Date(2000, 1, 1, 23, 59, 59, 0, time.Local),
+			Expected: false,
+		},
+		{
+			Name:     "after the expiration date",
+			Expires:  expires,
+			Now:      time.Date(2000, 1, 2, 0, 0, 0, 0, time.Local),
+			Expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		annotation := Annotation{Content: "test_rule", Expires: tc.Expires}
+		if got := annotation.IsExpired(tc.Now); got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected=%t, got=%t", tc.Name, tc.Expected, got)
+		}
+	}
+}
+
 func Test_IsAffected(t *testing.T) {
 	issue := &Issue{
 		Rule:    &testRule{},
@@ -163,6 +307,82 @@ func Test_IsAffected(t *testing.T) {
 			},
 			Expected: false,
 		},
+		{
+			Name: "affected (multiple rules)",
+			Annotation: Annotation{
+				Content: "test_another_rule, test_rule",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2},
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "affected (file)",
+			Annotation: Annotation{
+				Content: "test_rule",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 10},
+					},
+				},
+				Type: FileAnnotation,
+			},
+			Expected: true,
+		},
+		{
+			Name: "affected (range)",
+			Annotation: Annotation{
+				Content: "test_rule",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1},
+					},
+				},
+				Type:    RangeAnnotation,
+				EndLine: 3,
+			},
+			Expected: true,
+		},
+		{
+			Name: "not affected (out of range)",
+			Annotation: Annotation{
+				Content: "test_rule",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3},
+					},
+				},
+				Type:    RangeAnnotation,
+				EndLine: 5,
+			},
+			Expected: false,
+		},
+		{
+			Name: "affected (block)",
+			Annotation: Annotation{
+				Content: "test_rule",
+				Token: hclsyntax.Token{
+					Type: hclsyntax.TokenComment,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 0},
+					},
+				},
+				EndLine: 5,
+			},
+			Expected: true,
+		},
 		{
 			Name: "affected (all)",
 			Annotation: Annotation{
@@ -0,0 +1,189 @@
+package tflint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spf13/afero"
+)
+
+// Baseline is a set of issues recorded at a point in time.
+// Issues in the baseline are treated as pre-existing issues and are not reported.
+type Baseline struct {
+	Issues []*BaselineIssue `json:"issues"`
+}
+
+// BaselineIssue is an issue recorded in the baseline.
+// The fingerprint is built from the rule name, file name, block address and
+// normalized snippet of the flagged code, so it does not change when lines are
+// added or removed elsewhere in the file.
+type BaselineIssue struct {
+	Rule        string `json:"rule"`
+	Filename    string `json:"filename"`
+	Address     string `json:"address,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// NewBaseline returns a baseline recording the passed issues.
+func NewBaseline(issues Issues, sources map[string][]byte) *Baseline {
+	sorted := make(Issues, len(issues))
+	copy(sorted, issues)
+
+	fingerprinter := newFingerprinter(sources)
+	baseline := &Baseline{Issues: []*BaselineIssue{}}
+	for _, issue := range sorted.Sort() {
+		baseline.Issues = append(baseline.Issues, fingerprinter.baselineIssue(issue))
+	}
+	return baseline
+}
+
+// Fingerprints returns the fingerprints of the passed issues in the same order.
+// They are the same as the fingerprints recorded in the baseline.
+func Fingerprints(issues Issues, sources map[string][]byte) []string {
+	fingerprinter := newFingerprinter(sources)
+	ret := make([]string, len(issues))
+	for i, issue := range issues {
+		ret[i] = fingerprinter.baselineIssue(issue).Fingerprint
+	}
+	return ret
+}
+
+// LoadBaseline reads the baseline file from the passed path.
+func LoadBaseline(fs afero.Afero, path string) (*Baseline, error) {
+	src, err := fs.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("`%s` is not found", path)
+		}
+		return nil, err
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(src, &baseline); err != nil {
+		return nil, fmt.Errorf("`%s` is not a valid baseline file; %w", path, err)
+	}
+	return &baseline, nil
+}
+
+// Write writes the baseline to the passed path as JSON.
+func (b *Baseline) Write(fs afero.Afero, path string) error {
+	out, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(path, append(out, '\n'), 0644)
+}
+
+// Filter removes issues recorded in the baseline from the passed issues.
+// Each entry in the baseline suppresses at most one issue, so new occurrences of
+// the same code are still reported. It also returns entries that no longer match
+// any issue, which can be pruned from the baseline.
+func (b *Baseline) Filter(issues Issues, sources map[string][]byte) (Issues, []*BaselineIssue) {
+	remaining := map[string]int{}
+	for _, entry := range b.Issues {
+		remaining[entry.Fingerprint]++
+	}
+
+	fingerprinter := newFingerprinter(sources)
+	ret := Issues{}
+	for _, issue := range issues {
+		fingerprint := fingerprinter.baselineIssue(issue).Fingerprint
+		if remaining[fingerprint] > 0 {
+			remaining[fingerprint]--
+			continue
+		}
+		ret = append(ret, issue)
+	}
+
+	stale := []*BaselineIssue{}
+	for _, entry := range b.Issues {
+		if remaining[entry.Fingerprint] > 0 {
+			remaining[entry.Fingerprint]--
+			stale = append(stale, entry)
+		}
+	}
+
+	return ret, stale
+}
+
+// fingerprinter computes baseline fingerprints of issues.
+// Files are parsed lazily to find the block containing an issue.
+type fingerprinter struct {
+	sources map[string][]byte
+	bodies  map[string]*hclsyntax.Body
+}
+
+func newFingerprinter(sources map[string][]byte) *fingerprinter {
+	return &fingerprinter{
+		sources: sources,
+		bodies:  map[string]*hclsyntax.Body{},
+	}
+}
+
+func (f *fingerprinter) baselineIssue(issue *Issue) *BaselineIssue {
+	filename := filepath.ToSlash(issue.Range.Filename)
+	address := f.blockAddress(issue.Range)
+	snippet := f.snippet(issue.Range)
+
+	hash := sha256.New()
+	for _, part := range []string{issue.Rule.Name(), filename, address, snippet} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+
+	return &BaselineIssue{
+		Rule:        issue.Rule.Name(),
+		Filename:    filename,
+		Address:     address,
+		Fingerprint: hex.EncodeToString(hash.Sum(nil)),
+	}
+}
+
+// snippet returns the source code in the range with whitespace normalized.
+func (f *fingerprinter) snippet(rng hcl.Range) string {
+	src, exists := f.sources[rng.Filename]
+	if !exists || rng.Start.Byte > rng.End.Byte || rng.End.Byte > len(src) {
+		return ""
+	}
+	return strings.Join(strings.Fields(string(src[rng.Start.Byte:rng.End.Byte])), " ")
+}
+
+// blockAddress returns the address of the top-level block containing the range,
+// like `aws_instance.web` or `module.vpc`. JSON syntax files are not supported
+// and an empty string is returned.
+func (f *fingerprinter) blockAddress(rng hcl.Range) string {
+	body, exists := f.bodies[rng.Filename]
+	if !exists {
+		if src, ok := f.sources[rng.Filename]; ok && strings.HasSuffix(rng.Filename, ".tf") {
+			file, diags := hclsyntax.ParseConfig(src, rng.Filename, hcl.InitialPos)
+			if !diags.HasErrors() {
+				body = file.Body.(*hclsyntax.Body)
+			}
+		}
+		f.bodies[rng.Filename] = body
+	}
+	if body == nil {
+		return ""
+	}
+
+	for _, block := range body.Blocks {
+		if block.Range().Start.Byte > rng.Start.Byte || rng.End.Byte > block.Range().End.Byte {
+			continue
+		}
+
+		switch block.Type {
+		case "resource":
+			return strings.Join(block.Labels, ".")
+		default:
+			return strings.Join(append([]string{block.Type}, block.Labels...), ".")
+		}
+	}
+	return ""
+}
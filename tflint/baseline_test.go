@@ -0,0 +1,116 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+func Test_Baseline(t *testing.T) {
+	original := []byte(`resource "aws_instance" "foo" {
+  instance_type = "t1.2xlarge"
+}
+
+resource "aws_instance" "bar" {
+  instance_type = "t1.2xlarge"
+}
+`)
+	issue := func(line int, start int, end int) *Issue {
+		return &Issue{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: line, Byte: start},
+				End:      hcl.Pos{Line: line, Byte: end},
+			},
+		}
+	}
+	// `"t1.2xlarge"` in the `foo` and `bar` blocks
+	fooIssue := issue(2, 50, 62)
+	barIssue := issue(6, 116, 128)
+
+	baseline := NewBaseline(Issues{barIssue, fooIssue}, map[string][]byte{"main.tf": original})
+
+	addresses := []string{}
+	for _, entry := range baseline.Issues {
+		addresses = append(addresses, entry.Address)
+	}
+	if diff := cmp.Diff([]string{"aws_instance.foo", "aws_instance.bar"}, addresses); diff != "" {
+		t.Fatal(diff)
+	}
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	if err := baseline.Write(fs, ".tflint-baseline.json"); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadBaseline(fs, ".tflint-baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(baseline, loaded); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Lines are inserted before the blocks, the `bar` issue is removed,
+	// and a new issue is added in the new `baz` block.
+	changed := []byte(`# comment
+
+resource "aws_instance" "foo" {
+  instance_type   =   "t1.2xlarge"
+}
+
+resource "aws_instance" "bar" {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "baz" {
+  instance_type = "t1.2xlarge"
+}
+`)
+	movedFooIssue := issue(4, 65, 77)
+	bazIssue := issue(12, 195, 207)
+
+	got, stale := loaded.Filter(Issues{movedFooIssue, bazIssue}, map[string][]byte{"main.tf": changed})
+	if diff := cmp.Diff(Issues{bazIssue}, got); diff != "" {
+		t.Fatal(diff)
+	}
+	expectedStale := []*BaselineIssue{baseline.Issues[1]}
+	if diff := cmp.Diff(expectedStale, stale); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_Baseline_duplicateIssues(t *testing.T) {
+	src := []byte(`resource "aws_instance" "foo" {}`)
+	issue := &Issue{
+		Rule:    &testRule{},
+		Message: "test",
+		Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Byte: 0}, End: hcl.Pos{Byte: 29}},
+	}
+	sources := map[string][]byte{"main.tf": src}
+
+	baseline := NewBaseline(Issues{issue}, sources)
+	got, stale := baseline.Filter(Issues{issue, issue}, sources)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 issue remains, but got %d", len(got))
+	}
+	if len(stale) != 0 {
+		t.Fatalf("Expected no stale entries, but got %d", len(stale))
+	}
+}
+
+func Test_LoadBaseline_notFound(t *testing.T) {
+	_, err := LoadBaseline(afero.Afero{Fs: afero.NewMemMapFs()}, "not_found.json")
+	if err == nil {
+		t.Fatal("Expected error is not occurred")
+	}
+
+	expected := "`not_found.json` is not found"
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
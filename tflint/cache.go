@@ -0,0 +1,215 @@
+package tflint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/spf13/afero"
+)
+
+// Cache stores issues found by runners on disk, so that unchanged modules are not checked again.
+// Results are keyed on a hash of everything that can affect them: the sources of the module
+// and its callers, the TFLint config, the variable values, and the versions of TFLint and plugins.
+// Each module has a single entry that is overwritten when the module changes.
+type Cache struct {
+	fs        afero.Afero
+	dir       string
+	salt      string
+	workspace string
+}
+
+type cacheEntry struct {
+	Key    string         `json:"key"`
+	Issues []*cachedIssue `json:"issues"`
+}
+
+type cachedIssue struct {
+	Rule     string      `json:"rule"`
+	Severity Severity    `json:"severity"`
+	Link     string      `json:"link"`
+	Message  string      `json:"message"`
+	Range    hcl.Range   `json:"range"`
+	Callers  []hcl.Range `json:"callers,omitempty"`
+	Edits    []TextEdit  `json:"edits,omitempty"`
+}
+
+// cachedRule is a rule restored from the cache
+type cachedRule struct {
+	name     string
+	severity Severity
+	link     string
+}
+
+func (r *cachedRule) Name() string       { return r.name }
+func (r *cachedRule) Severity() Severity { return r.severity }
+func (r *cachedRule) Link() string       { return r.link }
+
+// NewCache returns a cache stored under the TF data dir.
+// The salt must identify the rulesets in use, such as the names and versions of plugins.
+func NewCache(fs afero.Afero, salt string) *Cache {
+	return &Cache{
+		fs:        fs,
+		dir:       filepath.Join(getTFDataDir(), "tflint-cache"),
+		salt:      salt,
+		workspace: getTFWorkspace(),
+	}
+}
+
+// Load restores the issues of the passed runner from the cache.
+// It returns false if the results for the current state of the runner are not cached.
+// Issues are emitted again, so annotations in the current sources are applied to them.
+func (c *Cache) Load(runner *Runner) bool {
+	path, key := c.path(runner), c.key(runner)
+
+	src, err := c.fs.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[WARN] Failed to read the cache `%s`; %s", path, err)
+		}
+		return false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(src, &entry); err != nil {
+		log.Printf("[WARN] Failed to parse the cache `%s`; %s", path, err)
+		return false
+	}
+	if entry.Key != key {
+		log.Printf("[DEBUG] The cache of %s is outdated", runnerName(runner))
+		return false
+	}
+
+	log.Printf("[INFO] Reuse cached results of %s", runnerName(runner))
+	for _, cached := range entry.Issues {
+		runner.emitIssue(&Issue{
+			Rule:    &cachedRule{name: cached.Rule, severity: cached.Severity, link: cached.Link},
+			Message: cached.Message,
+			Range:   cached.Range,
+			Callers: cached.Callers,
+			Edits:   cached.Edits,
+		})
+	}
+	return true
+}
+
+// Save stores the issues of the passed runner, including suppressed issues, to the cache.
+func (c *Cache) Save(runner *Runner) error {
+	entry := &cacheEntry{Key: c.key(runner), Issues: []*cachedIssue{}}
+
+	runner.mu.Lock()
+	issues := append(append(Issues{}, runner.Issues...), runner.SuppressedIssues...)
+	runner.mu.Unlock()
+	for _, issue := range issues {
+		// Severity overrides are applied again when the issue is emitted on load
+		entry.Issues = append(entry.Issues, &cachedIssue{
+			Rule:     issue.Rule.Name(),
+			Severity: DefaultSeverity(issue.Rule),
+			Link:     issue.Rule.Link(),
+			Message:  issue.Message,
+			Range:    issue.Range,
+			Callers:  issue.Callers,
+			Edits:    issue.Edits,
+		})
+	}
+
+	out, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := c.fs.MkdirAll(c.dir, os.ModePerm); err != nil {
+		return err
+	}
+	return c.fs.WriteFile(c.path(runner), out, 0644)
+}
+
+// path returns the file of the cache entry for the module of the passed runner.
+// Modules are identified by the directory of the root module and the module path.
+func (c *Cache) path(runner *Runner) string {
+	root, err := filepath.Abs(runner.TFConfig.Root.Module.SourceDir)
+	if err != nil {
+		root = runner.TFConfig.Root.Module.SourceDir
+	}
+	sum := sha256.Sum256([]byte(root + "\n" + runner.TFConfig.Path.String()))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// key returns the hash of the inputs that determine the results of the passed runner.
+func (c *Cache) key(runner *Runner) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "tflint %s\n%s\nworkspace %s\n", Version, c.salt, c.workspace)
+	writeConfigDigest(h, runner.config)
+
+	// Issues in child modules are reported on module calls in the callers,
+	// so the sources of all the callers are included.
+	for cfg := runner.TFConfig; cfg != nil; cfg = cfg.Parent {
+		fmt.Fprintf(h, "module %s\n", cfg.Path.String())
+		dir := filepath.Clean(cfg.Module.SourceDir)
+		names := []string{}
+		for name := range runner.files {
+			if filepath.Dir(name) == dir {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(h, "file %s %d\n", name, len(runner.files[name].Bytes))
+			h.Write(runner.files[name].Bytes)
+		}
+	}
+
+	// Maps are printed with sorted keys
+	fmt.Fprintf(h, "variables %#v\n", runner.variableValues)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeConfigDigest writes the settings of the config that affect inspection
+func writeConfigDigest(h hash.Hash, config *Config) {
+	names := []string{}
+	for name := range config.Sources() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "config %s %d\n", name, len(config.Sources()[name]))
+		h.Write(config.Sources()[name])
+	}
+
+	fmt.Fprintf(h, "module=%t disabled_by_default=%t\n", config.Module, config.DisabledByDefault)
+	fmt.Fprintf(h, "ignore_module=%#v varfile=%#v variables=%#v\n", config.IgnoreModules, config.Varfiles, config.Variables)
+	fmt.Fprintf(h, "exclude_paths=%#v hard_exclude=%t\n", config.ExcludePaths, config.HardExclude)
+
+	names = []string{}
+	for name := range config.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rule := config.Rules[name]
+		fmt.Fprintf(h, "rule %s enabled=%t severity=%s include=%#v exclude=%#v\n", name, rule.Enabled, rule.Severity, rule.Include, rule.Exclude)
+	}
+
+	names = []string{}
+	for name := range config.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		plugin := config.Plugins[name]
+		fmt.Fprintf(h, "plugin %s enabled=%t version=%s source=%s\n", name, plugin.Enabled, plugin.Version, plugin.Source)
+	}
+}
+
+func runnerName(runner *Runner) string {
+	if runner.TFConfig.Path.IsRoot() {
+		return "root"
+	}
+	return runner.TFConfig.Path.String()
+}
@@ -0,0 +1,70 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spf13/afero"
+)
+
+func Test_Cache(t *testing.T) {
+	annotations := map[string]Annotations{
+		"main.tf": {
+			{
+				Content: "test_rule",
+				Token: hclsyntax.Token{
+					Type:  hclsyntax.TokenComment,
+					Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}},
+				},
+			},
+		},
+	}
+	files := map[string]string{"main.tf": `variable "foo" {}`}
+
+	cache := NewCache(afero.Afero{Fs: afero.NewMemMapFs()}, "plugin aws 0.1.0")
+
+	runner := testRunnerWithAnnotations(t, files, annotations)
+	if cache.Load(runner) {
+		t.Fatal("Expected the cache is missed before saving")
+	}
+	runner.EmitIssue(&testRule{}, "suppressed", hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 2}})
+	runner.EmitIssueWithFix(&testRule{}, "reported", hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 5}}, TextEdit{
+		Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 5}},
+		NewText: []byte("fixed"),
+	})
+	if err := cache.Save(runner); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := testRunnerWithAnnotations(t, files, annotations)
+	if !cache.Load(restored) {
+		t.Fatal("Expected the cache is hit")
+	}
+	opt := cmp.Comparer(func(x, y Rule) bool {
+		return x.Name() == y.Name() && x.Severity() == y.Severity() && x.Link() == y.Link()
+	})
+	if diff := cmp.Diff(runner.LookupIssues(), restored.LookupIssues(), opt); diff != "" {
+		t.Fatal(diff)
+	}
+	suppressed := restored.LookupSuppressedIssues()
+	if len(suppressed) != 1 || suppressed[0].Message != "suppressed" || suppressed[0].Suppression == nil {
+		t.Fatalf("Expected the suppressed issue is restored, but got %#v", suppressed)
+	}
+	restored.EmitUnusedAnnotations([]string{"test_rule"})
+	if issues := restored.LookupIssues(); len(issues) != 1 {
+		t.Fatalf("Expected the annotation is used, but got %d issues", len(issues))
+	}
+
+	changed := testRunnerWithAnnotations(t, map[string]string{"main.tf": `variable "bar" {}`}, annotations)
+	if cache.Load(changed) {
+		t.Fatal("Expected the cache is missed after the source is changed")
+	}
+
+	other := NewCache(afero.Afero{Fs: afero.NewMemMapFs()}, "plugin aws 0.2.0")
+	other.fs = cache.fs
+	if other.Load(testRunnerWithAnnotations(t, files, annotations)) {
+		t.Fatal("Expected the cache is missed after the plugin is updated")
+	}
+}
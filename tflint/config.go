@@ -1,17 +1,28 @@
 package tflint
 
 import (
+	"bytes"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/bmatcuk/doublestar"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/terraform/configs"
+	"github.com/terraform-linters/tflint/terraform/lang/funcs"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
 )
 
 var defaultConfigFile = ".tflint.hcl"
@@ -43,6 +54,13 @@ var innerConfigSchema = &hcl.BodySchema{
 		{Name: "disabled_by_default"},
 		{Name: "plugin_dir"},
 		{Name: "format"},
+		{Name: "minimum_failure_severity"},
+		{Name: "report_unused_annotations"},
+		{Name: "require_annotation_reason"},
+		{Name: "report_expired_annotations"},
+		{Name: "exclude_paths"},
+		{Name: "hard_exclude"},
+		{Name: "extends"},
 	},
 }
 
@@ -53,29 +71,41 @@ var validFormats = []string{
 	"junit",
 	"compact",
 	"sarif",
+	"github",
+	"gitlab",
+	"template",
 }
 
 // Config describes the behavior of TFLint
 type Config struct {
-	Module            bool
-	Force             bool
-	IgnoreModules     map[string]bool
-	Varfiles          []string
-	Variables         []string
-	DisabledByDefault bool
-	PluginDir         string
-	Format            string
-	Rules             map[string]*RuleConfig
-	Plugins           map[string]*PluginConfig
+	Module                   bool
+	Force                    bool
+	IgnoreModules            map[string]bool
+	Varfiles                 []string
+	Variables                []string
+	DisabledByDefault        bool
+	PluginDir                string
+	Format                   string
+	MinimumFailureSeverity   string
+	ReportUnusedAnnotations  bool
+	RequireAnnotationReason  bool
+	ReportExpiredAnnotations bool
+	ExcludePaths             []string
+	HardExclude              bool
+	Rules                    map[string]*RuleConfig
+	Plugins                  map[string]*PluginConfig
 
 	sources map[string][]byte
 }
 
 // RuleConfig is a TFLint's rule config
 type RuleConfig struct {
-	Name    string   `hcl:"name,label"`
-	Enabled bool     `hcl:"enabled"`
-	Body    hcl.Body `hcl:",remain"`
+	Name     string   `hcl:"name,label"`
+	Enabled  bool     `hcl:"enabled"`
+	Severity string   `hcl:"severity,optional"`
+	Include  []string `hcl:"include,optional"`
+	Exclude  []string `hcl:"exclude,optional"`
+	Body     hcl.Body `hcl:",remain"`
 }
 
 // PluginConfig is a TFLint's plugin config
@@ -114,7 +144,7 @@ func EmptyConfig() *Config {
 func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 	log.Printf("[INFO] Load config: %s", file)
 	if f, err := fs.Open(file); err == nil {
-		cfg, err := loadConfig(f)
+		cfg, err := loadConfig(fs, f, []string{})
 		if err != nil {
 			return nil, err
 		}
@@ -132,7 +162,7 @@ func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 
 	log.Printf("[INFO] Load config: %s", fallback)
 	if f, err := fs.Open(fallback); err == nil {
-		cfg, err := loadConfig(f)
+		cfg, err := loadConfig(fs, f, []string{})
 		if err != nil {
 			return nil, err
 		}
@@ -144,7 +174,9 @@ func LoadConfig(fs afero.Afero, file string) (*Config, error) {
 	return EmptyConfig(), nil
 }
 
-func loadConfig(file afero.File) (*Config, error) {
+// loadConfig reads the config file and the files it extends.
+// The passed stack is the files extending the file, which is used to detect circular references.
+func loadConfig(fs afero.Afero, file afero.File, stack []string) (*Config, error) {
 	src, err := afero.ReadAll(file)
 	if err != nil {
 		return nil, err
@@ -163,6 +195,9 @@ func loadConfig(file afero.File) (*Config, error) {
 
 	config := EmptyConfig()
 	config.sources = parser.Sources()
+	ctx := configEvalContext()
+	var extends []string
+	var extendsRange hcl.Range
 	for _, block := range content.Blocks {
 		switch block.Type {
 		case "config":
@@ -174,35 +209,35 @@ func loadConfig(file afero.File) (*Config, error) {
 			for name, attr := range inner.Attributes {
 				switch name {
 				case "module":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Module); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.Module); err != nil {
 						return config, err
 					}
 				case "force":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Force); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.Force); err != nil {
 						return config, err
 					}
 				case "ignore_module":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.IgnoreModules); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.IgnoreModules); err != nil {
 						return config, err
 					}
 				case "varfile":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Varfiles); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.Varfiles); err != nil {
 						return config, err
 					}
 				case "variables":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Variables); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.Variables); err != nil {
 						return config, err
 					}
 				case "disabled_by_default":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.DisabledByDefault); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.DisabledByDefault); err != nil {
 						return config, err
 					}
 				case "plugin_dir":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.PluginDir); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.PluginDir); err != nil {
 						return config, err
 					}
 				case "format":
-					if err := gohcl.DecodeExpression(attr.Expr, nil, &config.Format); err != nil {
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.Format); err != nil {
 						return config, err
 					}
 					formatValid := false
@@ -215,19 +250,59 @@ func loadConfig(file afero.File) (*Config, error) {
 					if !formatValid {
 						return config, fmt.Errorf("%s is invalid format. Allowed formats are: %s", config.Format, strings.Join(validFormats, ", "))
 					}
+				case "minimum_failure_severity":
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.MinimumFailureSeverity); err != nil {
+						return config, err
+					}
+					if _, err := ParseSeverity(config.MinimumFailureSeverity); err != nil {
+						return config, err
+					}
+				case "report_unused_annotations":
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.ReportUnusedAnnotations); err != nil {
+						return config, err
+					}
+				case "require_annotation_reason":
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.RequireAnnotationReason); err != nil {
+						return config, err
+					}
+				case "report_expired_annotations":
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.ReportExpiredAnnotations); err != nil {
+						return config, err
+					}
+				case "exclude_paths":
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.ExcludePaths); err != nil {
+						return config, err
+					}
+					for _, pattern := range config.ExcludePaths {
+						if err := ValidatePattern(pattern); err != nil {
+							return config, err
+						}
+					}
+				case "hard_exclude":
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &config.HardExclude); err != nil {
+						return config, err
+					}
+				case "extends":
+					if err := gohcl.DecodeExpression(attr.Expr, ctx, &extends); err != nil {
+						return config, err
+					}
+					extendsRange = attr.Expr.Range()
 				default:
 					panic("never happened")
 				}
 			}
 		case "rule":
 			ruleConfig := &RuleConfig{Name: block.Labels[0]}
-			if err := gohcl.DecodeBody(block.Body, nil, ruleConfig); err != nil {
+			if err := gohcl.DecodeBody(block.Body, ctx, ruleConfig); err != nil {
+				return config, err
+			}
+			if err := ruleConfig.validate(); err != nil {
 				return config, err
 			}
 			config.Rules[block.Labels[0]] = ruleConfig
 		case "plugin":
 			pluginConfig := &PluginConfig{Name: block.Labels[0]}
-			if err := gohcl.DecodeBody(block.Body, nil, pluginConfig); err != nil {
+			if err := gohcl.DecodeBody(block.Body, ctx, pluginConfig); err != nil {
 				return config, err
 			}
 			if err := pluginConfig.validate(); err != nil {
@@ -239,6 +314,15 @@ func loadConfig(file afero.File) (*Config, error) {
 		}
 	}
 
+	if len(extends) > 0 {
+		base, err := loadExtendedConfigs(fs, file.Name(), extends, extendsRange, stack)
+		if err != nil {
+			return config, err
+		}
+		base.Merge(config)
+		config = base
+	}
+
 	log.Printf("[DEBUG] Config loaded")
 	log.Printf("[DEBUG]   Module: %t", config.Module)
 	log.Printf("[DEBUG]   Force: %t", config.Force)
@@ -251,9 +335,19 @@ func loadConfig(file afero.File) (*Config, error) {
 	log.Printf("[DEBUG]   DisabledByDefault: %t", config.DisabledByDefault)
 	log.Printf("[DEBUG]   PluginDir: %s", config.PluginDir)
 	log.Printf("[DEBUG]   Format: %s", config.Format)
+	log.Printf("[DEBUG]   MinimumFailureSeverity: %s", config.MinimumFailureSeverity)
+	log.Printf("[DEBUG]   ReportUnusedAnnotations: %t", config.ReportUnusedAnnotations)
+	log.Printf("[DEBUG]   RequireAnnotationReason: %t", config.RequireAnnotationReason)
+	log.Printf("[DEBUG]   ReportExpiredAnnotations: %t", config.ReportExpiredAnnotations)
+	log.Printf("[DEBUG]   ExcludePaths: %s", strings.Join(config.ExcludePaths, ", "))
+	log.Printf("[DEBUG]   HardExclude: %t", config.HardExclude)
 	log.Printf("[DEBUG]   Rules:")
 	for name, rule := range config.Rules {
-		log.Printf("[DEBUG]     %s: %t", name, rule.Enabled)
+		if rule.Severity != "" {
+			log.Printf("[DEBUG]     %s: %t, severity=%s", name, rule.Enabled, rule.Severity)
+		} else {
+			log.Printf("[DEBUG]     %s: %t", name, rule.Enabled)
+		}
 	}
 	log.Printf("[DEBUG]   Plugins:")
 	for name, plugin := range config.Plugins {
@@ -263,6 +357,117 @@ func loadConfig(file afero.File) (*Config, error) {
 	return config, nil
 }
 
+// configEvalContext returns the context to evaluate expressions in config files.
+// In addition to reading environment variables, only functions without side effects are available.
+// coalesce follows Terraform, which skips empty strings as well as nulls.
+func configEvalContext() *hcl.EvalContext {
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"terraform": cty.ObjectVal(map[string]cty.Value{
+				"workspace": cty.StringVal(getTFWorkspace()),
+			}),
+		},
+		Functions: map[string]function.Function{
+			"env":      envFunc,
+			"format":   stdlib.FormatFunc,
+			"lower":    stdlib.LowerFunc,
+			"upper":    stdlib.UpperFunc,
+			"concat":   stdlib.ConcatFunc,
+			"join":     stdlib.JoinFunc,
+			"coalesce": funcs.CoalesceFunc,
+		},
+	}
+}
+
+// envFunc returns the value of the environment variable.
+// If the variable is not set, it returns an empty string, so that a default can be given with coalesce().
+var envFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "name", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(os.Getenv(args[0].AsString())), nil
+	},
+})
+
+// loadExtendedConfigs loads the files extended by the config file, and merges them in order.
+// Relative paths are resolved from the directory of the config file.
+func loadExtendedConfigs(fs afero.Afero, filename string, extends []string, rng hcl.Range, stack []string) (*Config, error) {
+	stack = append(stack, filepath.Clean(filename))
+
+	base := EmptyConfig()
+	for _, path := range extends {
+		path, err := homedir.Expand(path)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(filename), path)
+		}
+		path = filepath.Clean(path)
+
+		for _, name := range stack {
+			if name == path {
+				return nil, fmt.Errorf("%s: circular extends: %s -> %s", rng, strings.Join(stack, " -> "), path)
+			}
+		}
+
+		log.Printf("[INFO] Load extended config: %s", path)
+		f, err := fs.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to load extended config `%s`; %w", rng, path, err)
+		}
+		extended, err := loadConfig(fs, f, stack)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		base.Merge(extended)
+	}
+	return base, nil
+}
+
+// FindConfigFiles returns the config files found from the directory up to the repository root,
+// ordered from the farthest one. The repository root is the nearest directory containing `.git`.
+// Outside of a repository, the config files are searched up to the filesystem root.
+func FindConfigFiles(fs afero.Afero, dir string) ([]string, error) {
+	current := filepath.Clean(dir)
+	abs, err := filepath.Abs(current)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for {
+		file := filepath.Join(current, defaultConfigFile)
+		exists, err := fs.Exists(file)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			log.Printf("[DEBUG] Config file found: %s", file)
+			files = append([]string{file}, files...)
+		}
+
+		root, err := fs.Exists(filepath.Join(current, ".git"))
+		if err != nil {
+			return nil, err
+		}
+		if root {
+			break
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			break
+		}
+		abs = parent
+		current = filepath.Join(current, "..")
+	}
+
+	return files, nil
+}
+
 // Sources returns parsed config file sources.
 // Normally, there is only one file, but it is represented by map to retain the file name.
 func (c *Config) Sources() map[string][]byte {
@@ -287,11 +492,33 @@ func (c *Config) Merge(other *Config) {
 	if other.Format != "" {
 		c.Format = other.Format
 	}
+	if other.MinimumFailureSeverity != "" {
+		c.MinimumFailureSeverity = other.MinimumFailureSeverity
+	}
+	if other.ReportUnusedAnnotations {
+		c.ReportUnusedAnnotations = true
+	}
+	if other.RequireAnnotationReason {
+		c.RequireAnnotationReason = true
+	}
+	if other.ReportExpiredAnnotations {
+		c.ReportExpiredAnnotations = true
+	}
+	if other.HardExclude {
+		c.HardExclude = true
+	}
 
 	for name, ignore := range other.IgnoreModules {
 		c.IgnoreModules[name] = ignore
 	}
+	for name, src := range other.sources {
+		if c.sources == nil {
+			c.sources = map[string][]byte{}
+		}
+		c.sources[name] = src
+	}
 	c.Varfiles = append(c.Varfiles, other.Varfiles...)
+	c.ExcludePaths = append(c.ExcludePaths, other.ExcludePaths...)
 	c.Variables = append(c.Variables, other.Variables...)
 
 	for name, rule := range other.Rules {
@@ -299,6 +526,8 @@ func (c *Config) Merge(other *Config) {
 		//       In this case, only override Enabled flag
 		if _, exists := c.Rules[name]; exists && rule.Body == nil {
 			c.Rules[name].Enabled = rule.Enabled
+		} else if base, exists := c.Rules[name]; exists && base.Body != nil {
+			c.Rules[name] = base.merge(rule)
 		} else {
 			c.Rules[name] = rule
 		}
@@ -309,12 +538,52 @@ func (c *Config) Merge(other *Config) {
 		//       In this case, only override Enabled flag
 		if _, exists := c.Plugins[name]; exists && plugin.Body == nil {
 			c.Plugins[name].Enabled = plugin.Enabled
+		} else if base, exists := c.Plugins[name]; exists && base.Body != nil {
+			c.Plugins[name] = base.merge(plugin)
 		} else {
 			c.Plugins[name] = plugin
 		}
 	}
 }
 
+// merge returns a new rule config that the passed config overrides.
+// Attributes and blocks in the body are overridden in the same way as Terraform override files.
+func (c *RuleConfig) merge(other *RuleConfig) *RuleConfig {
+	ret := &RuleConfig{
+		Name:     other.Name,
+		Enabled:  other.Enabled,
+		Severity: other.Severity,
+		Include:  other.Include,
+		Exclude:  other.Exclude,
+		Body:     configs.MergeBodies(c.Body, other.Body),
+	}
+	if ret.Severity == "" {
+		ret.Severity = c.Severity
+	}
+	if ret.Include == nil {
+		ret.Include = c.Include
+	}
+	if ret.Exclude == nil {
+		ret.Exclude = c.Exclude
+	}
+	return ret
+}
+
+// merge returns a new plugin config that the passed config overrides.
+// Attributes and blocks in the body are overridden in the same way as Terraform override files.
+func (c *PluginConfig) merge(other *PluginConfig) *PluginConfig {
+	ret := *other
+	if ret.Source == "" && ret.Version == "" {
+		ret.Source, ret.Version = c.Source, c.Version
+		ret.SourceOwner, ret.SourceRepo = c.SourceOwner, c.SourceRepo
+	}
+	if ret.SigningKey == "" {
+		ret.SigningKey = c.SigningKey
+	}
+	ret.Body = configs.MergeBodies(c.Body, other.Body)
+	return &ret
+}
+
 // ToPluginConfig converts self into the plugin configuration format
 func (c *Config) ToPluginConfig() *sdk.Config {
 	cfg := &sdk.Config{
@@ -330,6 +599,68 @@ func (c *Config) ToPluginConfig() *sdk.Config {
 	return cfg
 }
 
+// RuleSeverity returns the severity of the passed rule overridden by the config.
+// If the severity is not overridden, it returns the rule's own severity.
+func (c *Config) RuleSeverity(rule Rule) Severity {
+	if ruleConfig, exists := c.Rules[rule.Name()]; exists {
+		if severity, err := ParseSeverity(ruleConfig.Severity); err == nil {
+			return severity
+		}
+	}
+	return rule.Severity()
+}
+
+// ExcludesPath returns whether the file matches any of the exclude_paths patterns.
+func (c *Config) ExcludesPath(filename string) bool {
+	return matchPatterns(c.ExcludePaths, filename)
+}
+
+// MatchPath returns whether the rule applies to the file according to the include and exclude patterns.
+// If include patterns are set, the file must match any of them, and it must not match any exclude patterns.
+func (c *RuleConfig) MatchPath(filename string) bool {
+	if len(c.Include) > 0 && !matchPatterns(c.Include, filename) {
+		return false
+	}
+	return !matchPatterns(c.Exclude, filename)
+}
+
+// matchPatterns returns whether the file matches any of the patterns.
+// Patterns are doublestar globs matched against the file path relative to the current directory.
+func matchPatterns(patterns []string, filename string) bool {
+	path := filepath.ToSlash(filepath.Clean(filename))
+	for _, pattern := range patterns {
+		if matched, err := doublestar.Match(pattern, path); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePattern returns an error if the glob pattern is malformed.
+// doublestar has no function to validate patterns, so it matches the pattern against itself to parse it.
+func ValidatePattern(pattern string) error {
+	if _, err := doublestar.Match(pattern, pattern); err != nil {
+		return fmt.Errorf("`%s` is invalid pattern; %w", pattern, err)
+	}
+	return nil
+}
+
+func (c *RuleConfig) validate() error {
+	for _, pattern := range append(append([]string{}, c.Include...), c.Exclude...) {
+		if err := ValidatePattern(pattern); err != nil {
+			return fmt.Errorf("rule `%s`: %w", c.Name, err)
+		}
+	}
+
+	if c.Severity == "" {
+		return nil
+	}
+	if _, err := ParseSeverity(c.Severity); err != nil {
+		return fmt.Errorf("rule `%s`: %w", c.Name, err)
+	}
+	return nil
+}
+
 // Content extracts a plugin config based on the passed schema.
 func (c *PluginConfig) Content(schema *hclext.BodySchema) (*hclext.BodyContent, hcl.Diagnostics) {
 	if schema == nil {
@@ -341,6 +672,72 @@ func (c *PluginConfig) Content(schema *hclext.BodySchema) (*hclext.BodyContent,
 	return hclext.Content(c.Body, schema)
 }
 
+// EvaluateConfigContent evaluates expressions that require the eval context of config files like env(),
+// and replaces them with the literal values. Plugins decode config expressions without an eval context,
+// so the host must evaluate them. It returns the content and the sources to encode it.
+//
+// The plugin protocol sends expressions as source bytes, so the literals are appended to the returned sources,
+// and the expression ranges point to them. The attribute ranges keep pointing to the original expressions.
+// The passed sources are not modified.
+func EvaluateConfigContent(content *hclext.BodyContent, sources map[string][]byte) (*hclext.BodyContent, map[string][]byte) {
+	ret := map[string][]byte{}
+	for name, src := range sources {
+		ret[name] = src
+	}
+	return evaluateConfigContent(content, configEvalContext(), ret), ret
+}
+
+func evaluateConfigContent(content *hclext.BodyContent, ctx *hcl.EvalContext, sources map[string][]byte) *hclext.BodyContent {
+	if content == nil {
+		return nil
+	}
+
+	ret := &hclext.BodyContent{
+		Attributes: hclext.Attributes{},
+		Blocks:     make(hclext.Blocks, len(content.Blocks)),
+	}
+	for name, attr := range content.Attributes {
+		ret.Attributes[name] = attr
+
+		// Expressions that can be decoded by plugins are passed as is
+		if _, diags := attr.Expr.Value(nil); !diags.HasErrors() {
+			continue
+		}
+		val, diags := attr.Expr.Value(ctx)
+		if diags.HasErrors() || !val.IsWhollyKnown() {
+			continue
+		}
+		src, exists := sources[attr.Range.Filename]
+		if !exists {
+			continue
+		}
+
+		// Parse the literal at the appended position in the same way as plugins,
+		// so that the byte offsets, lines and columns of the expression are consistent with the sources
+		start := hcl.Pos{Line: bytes.Count(src, []byte("\n")) + 2, Column: 1, Byte: len(src) + 1}
+		literal := hclwrite.TokensForValue(val).Bytes()
+		expr, diags := hclsyntax.ParseExpression(literal, attr.Range.Filename, start)
+		if diags.HasErrors() {
+			log.Printf("[WARN] Failed to encode the value of `%s`; %s", attr.Name, diags)
+			continue
+		}
+		sources[attr.Range.Filename] = append(append(append([]byte{}, src...), '\n'), literal...)
+
+		ret.Attributes[name] = &hclext.Attribute{
+			Name:      attr.Name,
+			Expr:      expr,
+			Range:     attr.Range,
+			NameRange: attr.NameRange,
+		}
+	}
+	for i, block := range content.Blocks {
+		evaluated := *block
+		evaluated.Body = evaluateConfigContent(block.Body, ctx, sources)
+		ret.Blocks[i] = &evaluated
+	}
+	return ret
+}
+
 // RuleSet is an interface to handle plugin's RuleSet and core RuleSet both
 // In the future, when all RuleSets are cut out into plugins, it will no longer be needed.
 type RuleSet interface {
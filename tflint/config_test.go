@@ -1,8 +1,10 @@
 package tflint
 
 import (
+	"bytes"
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -12,6 +14,8 @@ import (
 	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	sdk "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/terraform/configs"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -33,6 +37,12 @@ func TestLoadConfig(t *testing.T) {
 config {
 	format = "compact"
 	plugin_dir = "~/.tflint.d/plugins"
+	minimum_failure_severity = "warning"
+	report_unused_annotations = true
+	require_annotation_reason = true
+	report_expired_annotations = true
+	exclude_paths = ["*.generated.tf"]
+	hard_exclude = true
 
 	module = true
 	force = true
@@ -55,6 +65,13 @@ rule "aws_instance_previous_type" {
 	foo = "bar"
 }
 
+rule "aws_instance_deprecated_type" {
+	enabled = true
+	severity = "notice"
+	include = ["modules/**"]
+	exclude = ["modules/examples/**"]
+}
+
 plugin "foo" {
 	enabled = true
 }
@@ -77,11 +94,17 @@ plugin "baz" {
 				IgnoreModules: map[string]bool{
 					"github.com/terraform-linters/example-module": true,
 				},
-				Varfiles:          []string{"example1.tfvars", "example2.tfvars"},
-				Variables:         []string{"foo=bar", "bar=['foo']"},
-				DisabledByDefault: false,
-				PluginDir:         "~/.tflint.d/plugins",
-				Format:            "compact",
+				Varfiles:                 []string{"example1.tfvars", "example2.tfvars"},
+				Variables:                []string{"foo=bar", "bar=['foo']"},
+				DisabledByDefault:        false,
+				PluginDir:                "~/.tflint.d/plugins",
+				Format:                   "compact",
+				MinimumFailureSeverity:   "warning",
+				ReportUnusedAnnotations:  true,
+				RequireAnnotationReason:  true,
+				ReportExpiredAnnotations: true,
+				ExcludePaths:             []string{"*.generated.tf"},
+				HardExclude:              true,
 				Rules: map[string]*RuleConfig{
 					"aws_instance_invalid_type": {
 						Name:    "aws_instance_invalid_type",
@@ -91,6 +114,13 @@ plugin "baz" {
 						Name:    "aws_instance_previous_type",
 						Enabled: false,
 					},
+					"aws_instance_deprecated_type": {
+						Name:     "aws_instance_deprecated_type",
+						Enabled:  true,
+						Severity: "notice",
+						Include:  []string{"modules/**"},
+						Exclude:  []string{"modules/examples/**"},
+					},
 				},
 				Plugins: map[string]*PluginConfig{
 					"foo": {
@@ -189,7 +219,48 @@ config {
 }`,
 			},
 			errCheck: func(err error) bool {
-				return err == nil || err.Error() != "invalid is invalid format. Allowed formats are: default, json, checkstyle, junit, compact, sarif"
+				return err == nil || err.Error() != "invalid is invalid format. Allowed formats are: default, json, checkstyle, junit, compact, sarif, github, gitlab, template"
+			},
+		},
+		{
+			name: "invalid minimum failure severity",
+			file: "invalid_minimum_failure_severity.hcl",
+			files: map[string]string{
+				"invalid_minimum_failure_severity.hcl": `
+config {
+	minimum_failure_severity = "fatal"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "fatal is invalid severity. Allowed severities are: error, warning, notice"
+			},
+		},
+		{
+			name: "invalid severity",
+			file: "invalid_severity.hcl",
+			files: map[string]string{
+				"invalid_severity.hcl": `
+rule "aws_instance_invalid_type" {
+	enabled = true
+	severity = "fatal"
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "rule `aws_instance_invalid_type`: fatal is invalid severity. Allowed severities are: error, warning, notice"
+			},
+		},
+		{
+			name: "invalid include pattern",
+			file: "invalid_include_pattern.hcl",
+			files: map[string]string{
+				"invalid_include_pattern.hcl": `
+rule "aws_instance_invalid_type" {
+	enabled = true
+	include = ["modules/[a-"]
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "rule `aws_instance_invalid_type`: `modules/[a-` is invalid pattern; syntax error in pattern"
 			},
 		},
 		{
@@ -254,6 +325,140 @@ plugin "foo" {
 				return err == nil || err.Error() != "plugin `foo`: `source` is invalid. Hostname must be `github.com`"
 			},
 		},
+		{
+			name: "extends",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+	extends = ["shared/base.hcl", "shared/team.hcl"]
+	varfile = ["local.tfvars"]
+}
+
+rule "aws_instance_invalid_type" {
+	enabled = true
+}
+
+plugin "foo" {
+	enabled = false
+}`,
+				"shared/base.hcl": `
+config {
+	format = "compact"
+	force = true
+	varfile = ["base.tfvars"]
+}
+
+rule "aws_instance_invalid_type" {
+	enabled = false
+	severity = "notice"
+}
+
+rule "aws_instance_previous_type" {
+	enabled = true
+}
+
+plugin "foo" {
+	enabled = true
+	version = "0.1.0"
+	source = "github.com/foo/bar"
+}`,
+				"shared/team.hcl": `
+config {
+	format = "json"
+}
+
+rule "aws_instance_previous_type" {
+	enabled = false
+}`,
+			},
+			want: &Config{
+				Force:         true,
+				IgnoreModules: map[string]bool{},
+				Varfiles:      []string{"base.tfvars", "local.tfvars"},
+				Variables:     []string{},
+				Format:        "json",
+				Rules: map[string]*RuleConfig{
+					"aws_instance_invalid_type": {
+						Name:     "aws_instance_invalid_type",
+						Enabled:  true,
+						Severity: "notice",
+					},
+					"aws_instance_previous_type": {
+						Name:    "aws_instance_previous_type",
+						Enabled: false,
+					},
+				},
+				Plugins: map[string]*PluginConfig{
+					"foo": {
+						Name:        "foo",
+						Enabled:     false,
+						Version:     "0.1.0",
+						Source:      "github.com/foo/bar",
+						SourceOwner: "foo",
+						SourceRepo:  "bar",
+					},
+				},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "nested extends",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+	extends = ["shared/team.hcl"]
+}`,
+				"shared/team.hcl": `
+config {
+	extends = ["../base.hcl"]
+}`,
+				"base.hcl": `
+config {
+	module = true
+}`,
+			},
+			want: &Config{
+				Module:        true,
+				IgnoreModules: map[string]bool{},
+				Varfiles:      []string{},
+				Variables:     []string{},
+				Rules:         map[string]*RuleConfig{},
+				Plugins:       map[string]*PluginConfig{},
+			},
+			errCheck: neverHappend,
+		},
+		{
+			name: "circular extends",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+	extends = ["base.hcl"]
+}`,
+				"base.hcl": `
+config {
+	extends = ["config.hcl"]
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "base.hcl:3,12-26: circular extends: config.hcl -> base.hcl -> config.hcl"
+			},
+		},
+		{
+			name: "extended file not found",
+			file: "config.hcl",
+			files: map[string]string{
+				"config.hcl": `
+config {
+	extends = ["not_found.hcl"]
+}`,
+			},
+			errCheck: func(err error) bool {
+				return err == nil || err.Error() != "config.hcl:3,12-29: failed to load extended config `not_found.hcl`; open not_found.hcl: file does not exist"
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -283,6 +488,216 @@ plugin "foo" {
 	}
 }
 
+func TestLoadConfig_evalContext(t *testing.T) {
+	t.Setenv("TFLINT_TEST_ENV", "Production")
+	t.Setenv("TF_WORKSPACE", "staging")
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	src := `
+config {
+	plugin_dir = format("%s/plugins", env("TFLINT_TEST_ENV"))
+	varfile = concat(["common.tfvars"], ["envs/${lower(env("TFLINT_TEST_ENV"))}.tfvars"])
+	variables = ["workspace=${terraform.workspace}", "region=${coalesce(env("TFLINT_TEST_UNSET"), "us-east-1")}"]
+}
+
+rule "aws_instance_invalid_type" {
+	enabled = env("TFLINT_TEST_ENV") == "Production"
+}`
+	if err := fs.WriteFile("config.hcl", []byte(src), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadConfig(fs, "config.hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Config{
+		IgnoreModules: map[string]bool{},
+		Varfiles:      []string{"common.tfvars", "envs/production.tfvars"},
+		Variables:     []string{"workspace=staging", "region=us-east-1"},
+		PluginDir:     "Production/plugins",
+		Rules: map[string]*RuleConfig{
+			"aws_instance_invalid_type": {
+				Name:    "aws_instance_invalid_type",
+				Enabled: true,
+			},
+		},
+		Plugins: map[string]*PluginConfig{},
+	}
+	opts := []cmp.Option{
+		cmpopts.IgnoreUnexported(Config{}),
+		cmpopts.IgnoreFields(RuleConfig{}, "Body"),
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestEvaluateConfigContent(t *testing.T) {
+	t.Setenv("TFLINT_TEST_ENV", "Production")
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	src := `
+plugin "test" {
+	enabled = true
+
+	foo = "bar"
+	env = lower(env("TFLINT_TEST_ENV"))
+
+	nested {
+		list = concat(["a"], [env("TFLINT_TEST_ENV")])
+	}
+}`
+	if err := fs.WriteFile("config.hcl", []byte(src), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(fs, "config.hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, diags := cfg.Plugins["test"].Content(&hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "foo"}, {Name: "env"}},
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "nested",
+				Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "list"}}},
+			},
+		},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	got, sources := EvaluateConfigContent(content, cfg.Sources())
+
+	// Plugins re-parse expressions from the sources and evaluate them without an eval context
+	decode := func(attr *hclext.Attribute) cty.Value {
+		rng := attr.Expr.Range()
+		expr, diags := hclext.ParseExpression(rng.SliceBytes(sources[rng.Filename]), rng.Filename, rng.Start)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		val, diags := expr.Value(nil)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		return val
+	}
+
+	// Byte offsets must point to the same positions as lines and columns in the sources
+	pos := func(src []byte, offset int) hcl.Pos {
+		line := bytes.Count(src[:offset], []byte("\n")) + 1
+		return hcl.Pos{Line: line, Column: offset - bytes.LastIndexByte(src[:offset], '\n'), Byte: offset}
+	}
+
+	tests := []struct {
+		Name string
+		Attr *hclext.Attribute
+		Want cty.Value
+		Line int
+	}{
+		{
+			Name: "literal",
+			Attr: got.Attributes["foo"],
+			Want: cty.StringVal("bar"),
+			Line: 5,
+		},
+		{
+			Name: "function call",
+			Attr: got.Attributes["env"],
+			Want: cty.StringVal("production"),
+			Line: 6,
+		},
+		{
+			Name: "nested block",
+			Attr: got.Blocks[0].Body.Attributes["list"],
+			Want: cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.StringVal("Production")}),
+			Line: 9,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if val := decode(test.Attr); !val.RawEquals(test.Want) {
+				t.Errorf("expected %#v, but got %#v", test.Want, val)
+			}
+			if line := test.Attr.Range.Start.Line; line != test.Line {
+				t.Errorf("expected line %d, but got %d", test.Line, line)
+			}
+			rng := test.Attr.Expr.Range()
+			for _, p := range []hcl.Pos{rng.Start, rng.End} {
+				if diff := cmp.Diff(pos(sources[rng.Filename], p.Byte), p); diff != "" {
+					t.Errorf("the expression range is inconsistent with the sources: %s", diff)
+				}
+			}
+		})
+	}
+
+	if string(cfg.Sources()["config.hcl"]) != src {
+		t.Error("the original sources must not be modified")
+	}
+	if !strings.HasPrefix(string(sources["config.hcl"]), src) {
+		t.Error("the original ranges must be valid in the returned sources")
+	}
+}
+
+func TestLoadConfig_extendsBody(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	files := map[string]string{
+		"config.hcl": `
+config {
+	extends = ["base.hcl"]
+}
+
+rule "aws_instance_invalid_type" {
+	enabled = true
+	bar = "override"
+}`,
+		"base.hcl": `
+rule "aws_instance_invalid_type" {
+	enabled = false
+	foo = "base"
+	bar = "base"
+}`,
+	}
+	for name, src := range files {
+		if err := fs.WriteFile(name, []byte(src), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := LoadConfig(fs, "config.hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attrs, diags := cfg.Rules["aws_instance_invalid_type"].Body.JustAttributes()
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	got := map[string]string{}
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		got[name] = val.AsString()
+	}
+	if diff := cmp.Diff(map[string]string{"foo": "base", "bar": "override"}, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	sources := []string{}
+	for name := range cfg.Sources() {
+		sources = append(sources, name)
+	}
+	if diff := cmp.Diff([]string{"base.hcl", "config.hcl"}, sources, cmpopts.SortSlices(func(x, y string) bool { return x < y })); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func TestMerge(t *testing.T) {
 	file1, diags := hclsyntax.ParseConfig([]byte(`foo = "bar"`), "test.hcl", hcl.Pos{})
 	if diags.HasErrors() {
@@ -438,7 +853,7 @@ func TestMerge(t *testing.T) {
 					"aws_instance_invalid_ami": {
 						Name:    "aws_instance_invalid_ami",
 						Enabled: false,
-						Body:    file2.Body,
+						Body:    configs.MergeBodies(file1.Body, file2.Body),
 					},
 					"aws_instance_previous_type": {
 						Name:    "aws_instance_previous_type",
@@ -703,6 +1118,118 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func Test_FindConfigFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		dir   string
+		files []string
+		dirs  []string
+		want  []string
+	}{
+		{
+			name:  "repository",
+			dir:   "/repo/child/grandchild",
+			files: []string{"/.tflint.hcl", "/repo/.tflint.hcl", "/repo/child/.tflint.hcl"},
+			dirs:  []string{"/repo/.git", "/repo/child/grandchild"},
+			want:  []string{"/repo/.tflint.hcl", "/repo/child/.tflint.hcl"},
+		},
+		{
+			name:  "outside of repository",
+			dir:   "/work/child",
+			files: []string{"/.tflint.hcl", "/work/child/.tflint.hcl"},
+			dirs:  []string{"/work/child"},
+			want:  []string{"/.tflint.hcl", "/work/child/.tflint.hcl"},
+		},
+		{
+			name:  "no config files",
+			dir:   "/repo/child",
+			files: []string{},
+			dirs:  []string{"/repo/.git", "/repo/child"},
+			want:  []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			for _, dir := range test.dirs {
+				if err := fs.MkdirAll(dir, os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, file := range test.files {
+				if err := fs.WriteFile(file, []byte{}, os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := FindConfigFiles(fs, test.dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestRuleConfig_MatchPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		filename string
+		want     bool
+	}{
+		{
+			name:     "no patterns",
+			filename: "main.tf",
+			want:     true,
+		},
+		{
+			name:     "included",
+			include:  []string{"modules/**"},
+			filename: "modules/vpc/main.tf",
+			want:     true,
+		},
+		{
+			name:     "not included",
+			include:  []string{"modules/**"},
+			filename: "examples/vpc/main.tf",
+			want:     false,
+		},
+		{
+			name:     "excluded",
+			exclude:  []string{"examples/**"},
+			filename: "examples/vpc/main.tf",
+			want:     false,
+		},
+		{
+			name:     "included but excluded",
+			include:  []string{"modules/**"},
+			exclude:  []string{"**/examples/**"},
+			filename: "modules/vpc/examples/main.tf",
+			want:     false,
+		},
+		{
+			name:     "not clean path",
+			include:  []string{"modules/**/*.tf"},
+			filename: "./modules/vpc/main.tf",
+			want:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule := &RuleConfig{Name: "test_rule", Enabled: true, Include: test.include, Exclude: test.exclude}
+			if got := rule.MatchPath(test.filename); got != test.want {
+				t.Fatalf("want=%t, got=%t", test.want, got)
+			}
+		})
+	}
+}
+
 func Test_ToPluginConfig(t *testing.T) {
 	src := `
 config {
@@ -0,0 +1,140 @@
+package tflint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// ChangedLines is a set of lines added or modified in a diff, keyed by the file name.
+type ChangedLines map[string]map[int]bool
+
+// ParseUnifiedDiff returns the lines added or modified in the passed unified diff.
+// Paths with the `b/` prefix like `git diff` are supported. Deleted files are ignored.
+func ParseUnifiedDiff(diff []byte) (ChangedLines, error) {
+	ret := ChangedLines{}
+
+	var filename string
+	// The next line number in the new file, and the numbers of lines remaining in the current hunk
+	var line, oldRemaining, newRemaining int
+	scanner := bufio.NewScanner(bytes.NewReader(diff))
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		if oldRemaining > 0 || newRemaining > 0 {
+			switch {
+			case strings.HasPrefix(text, "+"):
+				if filename != "" {
+					if _, exists := ret[filename]; !exists {
+						ret[filename] = map[int]bool{}
+					}
+					ret[filename][line] = true
+				}
+				line++
+				newRemaining--
+			case strings.HasPrefix(text, "-"):
+				oldRemaining--
+			case strings.HasPrefix(text, "\\"):
+				// "\ No newline at end of file"
+			default:
+				line++
+				oldRemaining--
+				newRemaining--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			filename = parseDiffPath(strings.TrimPrefix(text, "+++ "))
+		case strings.HasPrefix(text, "@@"):
+			match := hunkHeaderPattern.FindStringSubmatch(text)
+			if match == nil {
+				return ret, fmt.Errorf("Invalid hunk header: %s", text)
+			}
+			line, _ = strconv.Atoi(match[2])
+			oldRemaining = hunkLength(match[1])
+			newRemaining = hunkLength(match[3])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return ret, err
+	}
+
+	return ret, nil
+}
+
+// hunkLength returns the number of lines in the hunk header. It is 1 if omitted.
+func hunkLength(str string) int {
+	if str == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(str)
+	return n
+}
+
+func parseDiffPath(path string) string {
+	// Timestamps may follow the path after a tab
+	if idx := strings.Index(path, "\t"); idx >= 0 {
+		path = path[:idx]
+	}
+	if path == "/dev/null" {
+		return ""
+	}
+	if unquoted, err := strconv.Unquote(path); err == nil {
+		path = unquoted
+	}
+	path = strings.TrimPrefix(path, "b/")
+	return filepath.Clean(filepath.FromSlash(path))
+}
+
+// Contains checks if any line in the passed range is changed.
+func (c ChangedLines) Contains(rng hcl.Range) bool {
+	lines, exists := c[filepath.Clean(rng.Filename)]
+	if !exists {
+		return false
+	}
+	end := rng.End.Line
+	// The end position is exclusive. A range ending at the beginning of a line, such as a comment
+	// including the trailing newline, does not cover that line.
+	if rng.End.Column == 1 && end > rng.Start.Line {
+		end--
+	}
+	if end < rng.Start.Line {
+		end = rng.Start.Line
+	}
+	for line := rng.Start.Line; line <= end; line++ {
+		if lines[line] {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByChangedLines returns the issues on the changed lines.
+// Issues in modules are also returned if any of the module calls is on the changed lines.
+func (issues Issues) FilterByChangedLines(changes ChangedLines) Issues {
+	ret := Issues{}
+	for _, issue := range issues {
+		if changes.Contains(issue.Range) {
+			ret = append(ret, issue)
+			continue
+		}
+		for _, caller := range issue.Callers {
+			if changes.Contains(caller) {
+				ret = append(ret, issue)
+				break
+			}
+		}
+	}
+	return ret
+}
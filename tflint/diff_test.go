@@ -0,0 +1,126 @@
+package tflint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_ParseUnifiedDiff(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Diff     string
+		Expected ChangedLines
+	}{
+		{
+			Name: "git diff",
+			Diff: `diff --git a/main.tf b/main.tf
+index 1234567..89abcde 100644
+--- a/main.tf
++++ b/main.tf
+@@ -1,3 +1,4 @@
+ resource "aws_instance" "foo" {
+-  instance_type = "t2.micro"
++  instance_type = "t1.2xlarge"
++  ami           = "ami-12345678"
+ }
+@@ -10 +11,0 @@ locals {
+-  foo = "bar"
+@@ -20,0 +21 @@ locals {
+++++ not a header
+diff --git a/modules/vpc/main.tf b/modules/vpc/main.tf
+new file mode 100644
+--- /dev/null
++++ b/modules/vpc/main.tf
+@@ -0,0 +1,2 @@
++variable "cidr" {}
++variable "name" {}
+\ No newline at end of file
+diff --git a/deleted.tf b/deleted.tf
+deleted file mode 100644
+--- a/deleted.tf
++++ /dev/null
+@@ -1 +0,0 @@
+-locals {}
+`,
+			Expected: ChangedLines{
+				"main.tf": {2: true, 3: true, 21: true},
+				filepath.Join("modules", "vpc", "main.tf"): {1: true, 2: true},
+			},
+		},
+		{
+			Name: "diff -u",
+			Diff: `--- main.tf.orig	2024-01-01 00:00:00.000000000 +0900
++++ main.tf	2024-01-01 00:00:00.000000000 +0900
+@@ -1,2 +1,2 @@
+ locals {
+-  foo = "bar"
++  foo = "baz"
+`,
+			Expected: ChangedLines{
+				"main.tf": {2: true},
+			},
+		},
+		{
+			Name:     "empty",
+			Diff:     "",
+			Expected: ChangedLines{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := ParseUnifiedDiff([]byte(tc.Diff))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.Expected, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_ParseUnifiedDiff_invalidHunk(t *testing.T) {
+	_, err := ParseUnifiedDiff([]byte("+++ b/main.tf\n@@ invalid @@\n"))
+	if err == nil {
+		t.Fatal("Expected error is not occurred")
+	}
+}
+
+func Test_FilterByChangedLines(t *testing.T) {
+	changes := ChangedLines{
+		"main.tf": {3: true},
+	}
+	issue := func(filename string, start int, end int, callers ...hcl.Range) *Issue {
+		return &Issue{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: filename,
+				Start:    hcl.Pos{Line: start},
+				End:      hcl.Pos{Line: end},
+			},
+			Callers: callers,
+		}
+	}
+
+	onChangedLine := issue("main.tf", 3, 3)
+	overChangedLine := issue("main.tf", 2, 4)
+	calledOnChangedLine := issue(filepath.Join("module", "main.tf"), 1, 1, hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3}, End: hcl.Pos{Line: 3}})
+	notChanged := issue("main.tf", 1, 1)
+	calledNotChanged := issue(filepath.Join("module", "main.tf"), 1, 1, hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 5}, End: hcl.Pos{Line: 5}})
+	otherFile := issue("other.tf", 3, 3)
+	// e.g. a comment including the trailing newline
+	endsAtChangedLine := issue("main.tf", 2, 3)
+	endsAtChangedLine.Range.End.Column = 1
+
+	got := Issues{onChangedLine, overChangedLine, calledOnChangedLine, notChanged, calledNotChanged, otherFile, endsAtChangedLine}.FilterByChangedLines(changes)
+
+	expected := Issues{onChangedLine, overChangedLine, calledOnChangedLine}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,94 @@
+package tflint
+
+import (
+	"log"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// TextEdit represents a replacement of the source code in the range with the new text.
+// An empty range means the insertion of the new text.
+type TextEdit struct {
+	Range   hcl.Range
+	NewText []byte
+}
+
+// ApplyFixes applies the text edits attached to issues to the passed sources.
+// It returns only the changed sources and the issues whose edits were applied.
+//
+// Edits of an issue are applied all at once or not at all. If any of the edits
+// overlaps with an edit that has already been accepted, the issue is skipped
+// so that the result of the next inspection can be trusted.
+func ApplyFixes(issues Issues, sources map[string][]byte) (map[string][]byte, Issues) {
+	accepted := map[string][]TextEdit{}
+	fixed := Issues{}
+
+	sorted := make(Issues, len(issues))
+	copy(sorted, issues)
+
+	for _, issue := range sorted.Sort() {
+		if len(issue.Edits) == 0 {
+			continue
+		}
+		if !canApplyEdits(issue.Edits, accepted, sources) {
+			log.Printf("[INFO] Skip fixing %s (%s) because it conflicts with other fixes", issue.Range.String(), issue.Rule.Name())
+			continue
+		}
+
+		for _, edit := range issue.Edits {
+			accepted[edit.Range.Filename] = append(accepted[edit.Range.Filename], edit)
+		}
+		fixed = append(fixed, issue)
+	}
+
+	ret := map[string][]byte{}
+	for filename, edits := range accepted {
+		sort.Slice(edits, func(i, j int) bool {
+			return edits[i].Range.Start.Byte > edits[j].Range.Start.Byte
+		})
+
+		src := sources[filename]
+		out := make([]byte, len(src))
+		copy(out, src)
+		for _, edit := range edits {
+			tail := append([]byte{}, out[edit.Range.End.Byte:]...)
+			out = append(append(out[:edit.Range.Start.Byte], edit.NewText...), tail...)
+		}
+		ret[filename] = out
+	}
+
+	return ret, fixed
+}
+
+func canApplyEdits(edits []TextEdit, accepted map[string][]TextEdit, sources map[string][]byte) bool {
+	for i, edit := range edits {
+		src, exists := sources[edit.Range.Filename]
+		if !exists {
+			return false
+		}
+		if edit.Range.Start.Byte > edit.Range.End.Byte || edit.Range.End.Byte > len(src) {
+			return false
+		}
+
+		for _, other := range accepted[edit.Range.Filename] {
+			if editsOverlap(edit.Range, other.Range) {
+				return false
+			}
+		}
+		for _, other := range edits[:i] {
+			if other.Range.Filename == edit.Range.Filename && editsOverlap(edit.Range, other.Range) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func editsOverlap(a, b hcl.Range) bool {
+	if a.Start.Byte == b.Start.Byte {
+		// Multiple insertions at the same position are also ambiguous
+		return true
+	}
+	return a.Start.Byte < b.End.Byte && b.Start.Byte < a.End.Byte
+}
@@ -0,0 +1,124 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_ApplyFixes(t *testing.T) {
+	edit := func(filename string, start int, end int, text string) TextEdit {
+		return TextEdit{
+			Range: hcl.Range{
+				Filename: filename,
+				Start:    hcl.Pos{Line: 1, Column: start + 1, Byte: start},
+				End:      hcl.Pos{Line: 1, Column: end + 1, Byte: end},
+			},
+			NewText: []byte(text),
+		}
+	}
+	issue := func(edits ...TextEdit) *Issue {
+		ret := &Issue{Rule: &testRule{}, Message: "test", Edits: edits}
+		if len(edits) > 0 {
+			ret.Range = edits[0].Range
+		}
+		return ret
+	}
+
+	cases := []struct {
+		Name     string
+		Sources  map[string][]byte
+		Issues   Issues
+		Expected map[string]string
+		Fixed    int
+	}{
+		{
+			Name:     "no edits",
+			Sources:  map[string][]byte{"main.tf": []byte(`foo = "${var.foo}"`)},
+			Issues:   Issues{issue()},
+			Expected: map[string]string{},
+			Fixed:    0,
+		},
+		{
+			Name:    "single edit",
+			Sources: map[string][]byte{"main.tf": []byte(`foo = "${var.foo}"`)},
+			Issues: Issues{
+				issue(edit("main.tf", 6, 18, "var.foo")),
+			},
+			Expected: map[string]string{"main.tf": `foo = var.foo`},
+			Fixed:    1,
+		},
+		{
+			Name:    "multiple edits in a file",
+			Sources: map[string][]byte{"main.tf": []byte(`// foo = list.0`)},
+			Issues: Issues{
+				issue(edit("main.tf", 13, 15, "[0]")),
+				issue(edit("main.tf", 0, 2, "#")),
+			},
+			Expected: map[string]string{"main.tf": `# foo = list[0]`},
+			Fixed:    2,
+		},
+		{
+			Name: "multiple files",
+			Sources: map[string][]byte{
+				"main.tf":      []byte(`// foo`),
+				"variables.tf": []byte(`// bar`),
+			},
+			Issues: Issues{
+				issue(edit("main.tf", 0, 2, "#")),
+				issue(edit("variables.tf", 0, 2, "#")),
+			},
+			Expected: map[string]string{
+				"main.tf":      `# foo`,
+				"variables.tf": `# bar`,
+			},
+			Fixed: 2,
+		},
+		{
+			Name:    "conflicting edits",
+			Sources: map[string][]byte{"main.tf": []byte(`foo = [] == []`)},
+			Issues: Issues{
+				issue(edit("main.tf", 6, 14, "length([]) == 0")),
+				issue(edit("main.tf", 6, 14, "length([]) == 0")),
+			},
+			Expected: map[string]string{"main.tf": `foo = length([]) == 0`},
+			Fixed:    1,
+		},
+		{
+			Name:    "out of range",
+			Sources: map[string][]byte{"main.tf": []byte(`foo`)},
+			Issues: Issues{
+				issue(edit("main.tf", 0, 10, "bar")),
+			},
+			Expected: map[string]string{},
+			Fixed:    0,
+		},
+		{
+			Name:    "unknown file",
+			Sources: map[string][]byte{"main.tf": []byte(`foo`)},
+			Issues: Issues{
+				issue(edit("unknown.tf", 0, 1, "bar")),
+			},
+			Expected: map[string]string{},
+			Fixed:    0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, fixed := ApplyFixes(tc.Issues, tc.Sources)
+
+			gotStr := map[string]string{}
+			for name, src := range got {
+				gotStr[name] = string(src)
+			}
+			if diff := cmp.Diff(tc.Expected, gotStr); diff != "" {
+				t.Fatal(diff)
+			}
+			if len(fixed) != tc.Fixed {
+				t.Fatalf("expected %d issues to be fixed, but got %d", tc.Fixed, len(fixed))
+			}
+		})
+	}
+}
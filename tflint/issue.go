@@ -1,6 +1,7 @@
 package tflint
 
 import (
+	"fmt"
 	"sort"
 
 	hcl "github.com/hashicorp/hcl/v2"
@@ -13,6 +14,20 @@ type Issue struct {
 	Message string
 	Range   hcl.Range
 	Callers []hcl.Range
+	// Edits are text edits to fix the issue automatically. They are applied by `--fix`.
+	Edits []TextEdit
+	// Suppression is set if the issue is suppressed by an annotation
+	Suppression *Suppression
+}
+
+// Suppression represents why the issue is suppressed
+type Suppression struct {
+	Annotation *Annotation
+}
+
+// Justification returns the reason written in the annotation
+func (s *Suppression) Justification() string {
+	return s.Annotation.Reason
 }
 
 // Issues is an alias for the map of Issue
@@ -30,6 +45,32 @@ const (
 	NOTICE
 )
 
+var severities = map[string]Severity{
+	"error":   ERROR,
+	"warning": WARNING,
+	"notice":  NOTICE,
+}
+
+// ParseSeverity returns the severity represented by the passed string like "error".
+func ParseSeverity(str string) (Severity, error) {
+	severity, exists := severities[str]
+	if !exists {
+		return ERROR, fmt.Errorf("%s is invalid severity. Allowed severities are: error, warning, notice", str)
+	}
+	return severity, nil
+}
+
+// FilterBySeverity returns the issues whose severity is equal to or higher than the passed severity
+func (issues Issues) FilterBySeverity(minimum Severity) Issues {
+	ret := Issues{}
+	for _, issue := range issues {
+		if issue.Rule.Severity() <= minimum {
+			ret = append(ret, issue)
+		}
+	}
+	return ret
+}
+
 // Sort returns the sorted receiver
 func (issues Issues) Sort() Issues {
 	sort.Slice(issues, func(i, j int) bool {
@@ -50,7 +91,29 @@ func (issues Issues) Sort() Issues {
 		if iRange.End.Column != jRange.End.Column {
 			return iRange.End.Column > jRange.End.Column
 		}
-		return issues[i].Rule.Name() < issues[j].Rule.Name()
+		if issues[i].Rule.Name() != issues[j].Rule.Name() {
+			return issues[i].Rule.Name() < issues[j].Rule.Name()
+		}
+		if issues[i].Message != issues[j].Message {
+			return issues[i].Message < issues[j].Message
+		}
+		// Issues found in a module called multiple times differ only in the callers
+		return lessCallers(issues[i].Callers, issues[j].Callers)
 	})
 	return issues
 }
+
+func lessCallers(a []hcl.Range, b []hcl.Range) bool {
+	for idx := 0; idx < len(a) && idx < len(b); idx++ {
+		if a[idx].Filename != b[idx].Filename {
+			return a[idx].Filename < b[idx].Filename
+		}
+		if a[idx].Start.Line != b[idx].Start.Line {
+			return a[idx].Start.Line < b[idx].Start.Line
+		}
+		if a[idx].Start.Column != b[idx].Start.Column {
+			return a[idx].Start.Column < b[idx].Start.Column
+		}
+	}
+	return len(a) < len(b)
+}
@@ -127,3 +127,75 @@ func Test_Sort(t *testing.T) {
 		t.Fatalf("Failed: diff=%s", cmp.Diff(got, expected))
 	}
 }
+
+func Test_Sort_callers(t *testing.T) {
+	// The module is called twice, and the same issue is reported for each call
+	issue := func(callerLine int) *Issue {
+		return &Issue{
+			Rule:    &testRule{},
+			Message: "test",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1},
+				End:      hcl.Pos{Line: 1, Column: 2},
+			},
+			Callers: []hcl.Range{
+				{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 2}},
+				{Filename: "module/main.tf", Start: hcl.Pos{Line: callerLine, Column: 1}, End: hcl.Pos{Line: callerLine, Column: 2}},
+			},
+		}
+	}
+
+	for _, issues := range []Issues{{issue(5), issue(3)}, {issue(3), issue(5)}} {
+		got := issues.Sort()
+		if diff := cmp.Diff(Issues{issue(3), issue(5)}, got); diff != "" {
+			t.Fatalf("Failed: diff=%s", diff)
+		}
+	}
+}
+
+func Test_FilterBySeverity(t *testing.T) {
+	issues := Issues{
+		{Rule: &testRule{}, Message: "error"},
+		{Rule: &severityOverriddenRule{Rule: &testRule{}, severity: WARNING}, Message: "warning"},
+		{Rule: &severityOverriddenRule{Rule: &testRule{}, severity: NOTICE}, Message: "notice"},
+	}
+
+	cases := []struct {
+		Severity string
+		Expected []string
+	}{
+		{Severity: "error", Expected: []string{"error"}},
+		{Severity: "warning", Expected: []string{"error", "warning"}},
+		{Severity: "notice", Expected: []string{"error", "warning", "notice"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Severity, func(t *testing.T) {
+			severity, err := ParseSeverity(tc.Severity)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, issue := range issues.FilterBySeverity(severity) {
+				got = append(got, issue.Message)
+			}
+			if diff := cmp.Diff(tc.Expected, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_ParseSeverity_invalid(t *testing.T) {
+	_, err := ParseSeverity("fatal")
+	if err == nil {
+		t.Fatal("Expected error is not occurred")
+	}
+
+	expected := "fatal is invalid severity. Allowed severities are: error, warning, notice"
+	if err.Error() != expected {
+		t.Fatalf("Expected error is `%s`, but get `%s`", expected, err.Error())
+	}
+}
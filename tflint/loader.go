@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -28,6 +29,7 @@ type AbstractLoader interface {
 	LoadValuesFiles(...string) ([]terraform.InputValues, error)
 	Files() (map[string]*hcl.File, error)
 	Sources() map[string][]byte
+	SwitchRoot(string) error
 }
 
 // Loader is a wrapper of Terraform's configload.Loader
@@ -35,9 +37,11 @@ type Loader struct {
 	parser               *configs.Parser
 	fs                   afero.Afero
 	currentDir           string
+	baseDir              string
 	config               *Config
 	moduleSourceVersions map[string][]*version.Version
 	moduleManifest       map[string]*moduleManifest
+	files                map[string]*hcl.File
 }
 
 type moduleManifest struct {
@@ -59,22 +63,57 @@ func NewLoader(fs afero.Afero, cfg *Config) (*Loader, error) {
 	l := &Loader{
 		parser:               configs.NewParser(fs),
 		fs:                   fs,
+		baseDir:              ".",
 		config:               cfg,
 		moduleSourceVersions: map[string][]*version.Version{},
 		moduleManifest:       map[string]*moduleManifest{},
+		files:                map[string]*hcl.File{},
 	}
 
-	if _, err := os.Stat(getTFModuleManifestPath()); !os.IsNotExist(err) {
-		log.Print("[INFO] Module manifest file found. Initializing...")
-		if err := l.initializeModuleManifest(); err != nil {
-			log.Printf("[ERROR] %s", err)
-			return nil, err
-		}
+	if cfg.HardExclude {
+		l.parser.SetExcludeFunc(cfg.ExcludesPath)
+	}
+
+	if err := l.loadModuleManifest(); err != nil {
+		return nil, err
 	}
 
 	return l, nil
 }
 
+// SwitchRoot changes the directory where the loader looks up the module manifest
+// and automatically loaded values files. By default, the current directory is used.
+// This allows a single loader to load multiple root modules. Files that have already
+// been loaded are kept, so Sources returns the sources of all root modules.
+func (l *Loader) SwitchRoot(dir string) error {
+	log.Printf("[INFO] Switch the root module directory to %s", dir)
+
+	l.baseDir = dir
+	l.moduleSourceVersions = map[string][]*version.Version{}
+	l.moduleManifest = map[string]*moduleManifest{}
+
+	return l.loadModuleManifest()
+}
+
+// Invalidate discards the cached files and replaces the config, so that changed files are read
+// from disk on the next load. This allows a single loader to inspect the same files repeatedly.
+func (l *Loader) Invalidate(cfg *Config) error {
+	log.Print("[INFO] Invalidate the loader cache")
+
+	l.parser.ClearCache()
+	if cfg.HardExclude {
+		l.parser.SetExcludeFunc(cfg.ExcludesPath)
+	} else {
+		l.parser.SetExcludeFunc(nil)
+	}
+	l.config = cfg
+	l.files = map[string]*hcl.File{}
+	l.moduleSourceVersions = map[string][]*version.Version{}
+	l.moduleManifest = map[string]*moduleManifest{}
+
+	return l.loadModuleManifest()
+}
+
 // LoadConfig loads Terraform's configurations
 // TODO: Can we use configload.LoadConfig instead?
 func (l *Loader) LoadConfig(dir string) (*configs.Config, error) {
@@ -108,12 +147,23 @@ func (l *Loader) LoadConfig(dir string) (*configs.Config, error) {
 // Files returns a map of hcl.File pointers for every file that has been read by the loader.
 // It uses the source cache to avoid re-loading the files from disk. These files can be used
 // to do low level decoding of Terraform configuration.
+// Parsed files are cached, so files that have already been parsed for other root modules are not parsed again.
+// Files matching exclude_paths are not returned, although they are loaded into modules to resolve references.
 func (l *Loader) Files() (map[string]*hcl.File, error) {
 	sources := l.parser.Sources()
 	result := make(map[string]*hcl.File, len(sources))
 	parser := hclparse.NewParser()
 
 	for path, src := range sources {
+		if l.config.ExcludesPath(path) {
+			log.Printf("[DEBUG] %s is excluded from inspection", path)
+			continue
+		}
+		if file, exists := l.files[path]; exists {
+			result[path] = file
+			continue
+		}
+
 		var file *hcl.File
 		var diags hcl.Diagnostics
 		switch {
@@ -127,6 +177,7 @@ func (l *Loader) Files() (map[string]*hcl.File, error) {
 			return nil, diags
 		}
 
+		l.files[path] = file
 		result[path] = file
 	}
 
@@ -145,7 +196,8 @@ func (l *Loader) LoadAnnotations(dir string) (map[string]Annotations, error) {
 	ret := map[string]Annotations{}
 
 	for _, configFile := range configFiles {
-		if !strings.HasSuffix(configFile, ".tf") {
+		// Issues in excluded files are not reported, so annotations in them are never used
+		if !strings.HasSuffix(configFile, ".tf") || l.config.ExcludesPath(configFile) {
 			continue
 		}
 
@@ -182,8 +234,9 @@ func (l *Loader) LoadValuesFiles(files ...string) ([]terraform.InputValues, erro
 		log.Printf("[ERROR] %s", err)
 		return nil, err
 	}
-	if _, err := os.Stat(defaultValuesFile); !os.IsNotExist(err) {
-		autoLoadFiles = append([]string{defaultValuesFile}, autoLoadFiles...)
+	defaultFile := filepath.Join(l.baseDir, defaultValuesFile)
+	if _, err := os.Stat(defaultFile); !os.IsNotExist(err) {
+		autoLoadFiles = append([]string{defaultFile}, autoLoadFiles...)
 	}
 
 	for _, file := range autoLoadFiles {
@@ -209,11 +262,11 @@ func (l *Loader) Sources() map[string][]byte {
 	return l.parser.Sources()
 }
 
-// autoLoadValuesFiles returns all files which match *.auto.tfvars present in the current directory
+// autoLoadValuesFiles returns all files which match *.auto.tfvars present in the root module directory
 // The list is sorted alphabetically. This is equivalent to priority
 // Please note that terraform.tfvars is not included in this list
 func (l *Loader) autoLoadValuesFiles() ([]string, error) {
-	files, err := l.fs.ReadDir(".")
+	files, err := l.fs.ReadDir(l.baseDir)
 	if err != nil {
 		return nil, err
 	}
@@ -225,7 +278,7 @@ func (l *Loader) autoLoadValuesFiles() ([]string, error) {
 		}
 
 		if strings.HasSuffix(file.Name(), ".auto.tfvars") || strings.HasSuffix(file.Name(), ".auto.tfvars.json") {
-			ret = append(ret, file.Name())
+			ret = append(ret, filepath.Join(l.baseDir, file.Name()))
 		}
 	}
 	sort.Strings(ret)
@@ -283,8 +336,21 @@ func (l *Loader) ignoreModuleWalker() configs.ModuleWalker {
 	})
 }
 
+func (l *Loader) loadModuleManifest() error {
+	if _, err := os.Stat(filepath.Join(l.baseDir, getTFModuleManifestPath())); os.IsNotExist(err) {
+		return nil
+	}
+
+	log.Print("[INFO] Module manifest file found. Initializing...")
+	if err := l.initializeModuleManifest(); err != nil {
+		log.Printf("[ERROR] %s", err)
+		return err
+	}
+	return nil
+}
+
 func (l *Loader) initializeModuleManifest() error {
-	file, err := l.fs.ReadFile(getTFModuleManifestPath())
+	file, err := l.fs.ReadFile(filepath.Join(l.baseDir, getTFModuleManifestPath()))
 	if err != nil {
 		return err
 	}
@@ -297,6 +363,9 @@ func (l *Loader) initializeModuleManifest() error {
 	}
 
 	for _, m := range manifestFile.Modules {
+		// Module directories in the manifest are relative to the root module
+		m.Dir = filepath.Join(l.baseDir, m.Dir)
+
 		if m.VersionStr != "" {
 			m.Version, err = version.NewVersion(m.VersionStr)
 			if err != nil {
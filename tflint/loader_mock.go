@@ -113,3 +113,17 @@ func (mr *MockAbstractLoaderMockRecorder) Sources() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sources", reflect.TypeOf((*MockAbstractLoader)(nil).Sources))
 }
+
+// SwitchRoot mocks base method.
+func (m *MockAbstractLoader) SwitchRoot(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwitchRoot", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SwitchRoot indicates an expected call of SwitchRoot.
+func (mr *MockAbstractLoaderMockRecorder) SwitchRoot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwitchRoot", reflect.TypeOf((*MockAbstractLoader)(nil).SwitchRoot), arg0)
+}
@@ -2,6 +2,7 @@ package tflint
 
 import (
 	"fmt"
+	"os"
 	"reflect"
 	"testing"
 
@@ -79,6 +80,40 @@ func Test_LoadConfig_v0_15_0(t *testing.T) {
 	})
 }
 
+func Test_LoadConfig_switchRoot(t *testing.T) {
+	withinFixtureDir(t, ".", func() {
+		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, moduleConfig())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := loader.SwitchRoot("nested_modules"); err != nil {
+			t.Fatal(err)
+		}
+		config, err := loader.LoadConfig("nested_modules")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, exists := config.Children["root"].Children["test"]; !exists {
+			t.Fatalf("`root.test` module is not loaded: %#v", config.Children)
+		}
+
+		if err := loader.SwitchRoot("values_files"); err != nil {
+			t.Fatal(err)
+		}
+		values, err := loader.LoadValuesFiles()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(values) != 3 {
+			t.Fatalf("Expected 3 values files are loaded, but got %d", len(values))
+		}
+		if _, exists := values[0]["default"]; !exists {
+			t.Fatalf("`terraform.tfvars` is not loaded: %#v", values)
+		}
+	})
+}
+
 func Test_LoadConfig_moduleNotFound(t *testing.T) {
 	withinFixtureDir(t, "before_terraform_init", func() {
 		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, moduleConfig())
@@ -165,6 +200,107 @@ func Test_Files(t *testing.T) {
 	})
 }
 
+func Test_Files_excludePaths(t *testing.T) {
+	tests := []struct {
+		name        string
+		hardExclude bool
+		outputs     int
+	}{
+		{
+			name:        "soft exclude",
+			hardExclude: false,
+			outputs:     1,
+		},
+		{
+			name:        "hard exclude",
+			hardExclude: true,
+			outputs:     0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			if err := fs.WriteFile("main.tf", []byte(`variable "foo" {}`), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			if err := fs.WriteFile("foo.generated.tf", []byte(`output "foo" { value = var.foo }`), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := EmptyConfig()
+			cfg.ExcludePaths = []string{"*.generated.tf"}
+			cfg.HardExclude = test.hardExclude
+			loader, err := NewLoader(fs, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, err := loader.LoadConfig(".")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(config.Module.Outputs) != test.outputs {
+				t.Fatalf("Expected %d outputs in the module, but got %d", test.outputs, len(config.Module.Outputs))
+			}
+
+			files, err := loader.Files()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, exists := files["foo.generated.tf"]; exists || len(files) != 1 {
+				t.Fatalf("Expected only main.tf, but got %v", files)
+			}
+		})
+	}
+}
+
+func Test_Invalidate(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	if err := fs.WriteFile("main.tf", []byte(`variable "foo" {}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	loader, err := NewLoader(fs, EmptyConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loader.LoadConfig("."); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loader.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fs.WriteFile("main.tf", []byte(`variable "bar" {}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.WriteFile("foo.generated.tf", []byte(`variable "baz" {}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := EmptyConfig()
+	cfg.ExcludePaths = []string{"*.generated.tf"}
+	cfg.HardExclude = true
+	if err := loader.Invalidate(cfg); err != nil {
+		t.Fatal(err)
+	}
+	config, err := loader.LoadConfig(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := config.Module.Variables["bar"]; !exists || len(config.Module.Variables) != 1 {
+		t.Fatalf("Expected only `bar` variable, but got %v", config.Module.Variables)
+	}
+
+	files, err := loader.Files()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(files["main.tf"].Bytes) != `variable "bar" {}` {
+		t.Fatalf("Expected the changed main.tf, but got %s", files["main.tf"].Bytes)
+	}
+}
+
 func Test_LoadAnnotations(t *testing.T) {
 	withinFixtureDir(t, "annotation_files", func() {
 		loader, err := NewLoader(afero.Afero{Fs: afero.NewOsFs()}, EmptyConfig())
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
@@ -24,8 +25,9 @@ import (
 // For variables interplation, it has Terraform eval context.
 // After checking, it accumulates results as issues.
 type Runner struct {
-	TFConfig *configs.Config
-	Issues   Issues
+	TFConfig         *configs.Config
+	Issues           Issues
+	SuppressedIssues Issues
 
 	ctx         terraform.EvalContext
 	files       map[string]*hcl.File
@@ -33,11 +35,17 @@ type Runner struct {
 	config      *Config
 	currentExpr hcl.Expression
 	modVars     map[string]*moduleVariable
+	// variableValues are the input variable values of the module, used as a cache key
+	variableValues map[string]map[string]cty.Value
 
 	moduleSources         map[string][]byte
 	primaries             []*hcl.File
 	overrides             []*hcl.File
 	earlyDecodedResources map[string]map[string]*hclext.Block
+	affectedAnnotations   map[*Annotation]bool
+	// mu guards issues and affected annotations, so that rules can be checked concurrently.
+	// It is shared with module runners as well as affected annotations.
+	mu *sync.Mutex
 }
 
 // Rule is interface for building the issue
@@ -47,6 +55,25 @@ type Rule interface {
 	Link() string
 }
 
+// severityOverriddenRule is a rule whose severity is overridden by the `severity` attribute in the config.
+type severityOverriddenRule struct {
+	Rule
+	severity Severity
+}
+
+// Severity returns the overridden severity
+func (r *severityOverriddenRule) Severity() Severity {
+	return r.severity
+}
+
+// DefaultSeverity returns the severity of the rule before it is overridden by the config.
+func DefaultSeverity(rule Rule) Severity {
+	if r, ok := rule.(*severityOverriddenRule); ok {
+		return r.Rule.Severity()
+	}
+	return rule.Severity()
+}
+
 // NewRunner returns new TFLint runner
 // It prepares built-in context (workpace metadata, variables) from
 // received `configs.Config` and `terraform.InputValues`
@@ -93,8 +120,9 @@ func NewRunner(c *Config, files map[string]*hcl.File, ants map[string]Annotation
 	})
 
 	runner := &Runner{
-		TFConfig: cfg,
-		Issues:   Issues{},
+		TFConfig:         cfg,
+		Issues:           Issues{},
+		SuppressedIssues: Issues{},
 
 		// TODO: As described in the godoc for UnkeyedInstanceShim,
 		// it will need to be replaced now that module.for_each is supported
@@ -103,10 +131,14 @@ func NewRunner(c *Config, files map[string]*hcl.File, ants map[string]Annotation
 		annotations: ants,
 		config:      c,
 
+		variableValues: variableValues,
+
 		moduleSources:         sources,
 		primaries:             primaries,
 		overrides:             overrides,
 		earlyDecodedResources: map[string]map[string]*hclext.Block{},
+		affectedAnnotations:   map[*Annotation]bool{},
+		mu:                    &sync.Mutex{},
 	}
 
 	// Decode resource with count/for_each early
@@ -244,7 +276,7 @@ func NewModuleRunners(parent *Runner) ([]*Runner, error) {
 					}
 				} else {
 					parentVars := []*moduleVariable{}
-					for _, ref := range listVarRefs(attribute.Expr) {
+					for _, ref := range listReferencedVars(attribute.Expr, parent.TFConfig.Module.Locals) {
 						if parentVar, exists := parent.modVars[ref.Name]; exists {
 							parentVars = append(parentVars, parentVar)
 						}
@@ -262,6 +294,9 @@ func NewModuleRunners(parent *Runner) ([]*Runner, error) {
 			return runners, err
 		}
 		runner.modVars = modVars
+		// Share affected annotations to find unused annotations across all modules
+		runner.affectedAnnotations = parent.affectedAnnotations
+		runner.mu = parent.mu
 		runners = append(runners, runner)
 		moudleRunners, err := NewModuleRunners(runner)
 		if err != nil {
@@ -420,19 +455,190 @@ func (r *Runner) TFConfigPath() string {
 
 // LookupIssues returns issues according to the received files
 func (r *Runner) LookupIssues(files ...string) Issues {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return filterIssuesByFiles(r.Issues, files)
+}
+
+func filterIssuesByFiles(issues Issues, files []string) Issues {
 	if len(files) == 0 {
-		return r.Issues
+		return issues
 	}
 
-	issues := Issues{}
-	for _, issue := range r.Issues {
+	ret := Issues{}
+	for _, issue := range issues {
 		for _, file := range files {
 			if filepath.Clean(file) == filepath.Clean(issue.Range.Filename) {
-				issues = append(issues, issue)
+				ret = append(ret, issue)
 			}
 		}
 	}
-	return issues
+	return ret
+}
+
+// LookupSuppressedIssues returns issues suppressed by annotations according to the received files
+func (r *Runner) LookupSuppressedIssues(files ...string) Issues {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return filterIssuesByFiles(r.SuppressedIssues, files)
+}
+
+// EmitInvalidAnnotations emits issues for annotations that violate the annotation policy in the config.
+// If `require_annotation_reason` is enabled, annotations without a reason are reported.
+// If `report_expired_annotations` is enabled, annotations expired at the passed time are reported.
+// The issues are subject to exclude_paths and rule configs, but not to annotations,
+// since the offending annotations would otherwise suppress the issues about themselves.
+func (r *Runner) EmitInvalidAnnotations(now time.Time) {
+	if !r.config.RequireAnnotationReason && !r.config.ReportExpiredAnnotations {
+		return
+	}
+
+	filenames := []string{}
+	for filename := range r.annotations {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	unsuppressible := func(*Annotation) bool { return false }
+	for _, filename := range filenames {
+		for _, annotation := range r.annotations[filename] {
+			if r.config.RequireAnnotationReason && annotation.Reason == "" {
+				r.emitIssueWith(&Issue{
+					Rule:    &annotationWithoutReasonRule{},
+					Message: fmt.Sprintf("The annotation for `%s` has no reason. Write it after \"--\"", annotation.Content),
+					Range:   annotation.Token.Range,
+				}, unsuppressible)
+			}
+			if r.config.ReportExpiredAnnotations && annotation.IsExpired(now) {
+				r.emitIssueWith(&Issue{
+					Rule:    &expiredAnnotationRule{},
+					Message: fmt.Sprintf("The annotation for `%s` expired on %s", annotation.Content, annotation.Expires.Format("2006-01-02")),
+					Range:   annotation.Token.Range,
+				}, unsuppressible)
+			}
+		}
+	}
+}
+
+// EmitUnusedAnnotations emits issues for annotations that did not suppress any issues,
+// and for rule names in annotations that do not exist in the passed rule names.
+// It must be called on the root runner after all rules have been checked by all runners.
+// Like issues of other rules, the issues are subject to exclude_paths and rule configs, but only annotations
+// naming `tflint_unused_annotation` can suppress them. See canSuppressAnnotationIssue.
+func (r *Runner) EmitUnusedAnnotations(ruleNames []string) {
+	rules := map[string]bool{
+		"all":                                   true,
+		(&unusedAnnotationRule{}).Name():        true,
+		(&annotationWithoutReasonRule{}).Name(): true,
+		(&expiredAnnotationRule{}).Name():       true,
+	}
+	for _, name := range ruleNames {
+		rules[name] = true
+	}
+
+	r.mu.Lock()
+	used := map[*Annotation]bool{}
+	for annotation := range r.affectedAnnotations {
+		used[annotation] = true
+	}
+	r.mu.Unlock()
+
+	// Annotations suppressing the issues about other annotations are also used,
+	// so the issues are built again until no more annotations are found to be used.
+	issues, abouts := r.unusedAnnotationIssues(rules, used)
+	for r.markSuppressingAnnotations(issues, abouts, used) {
+		issues, abouts = r.unusedAnnotationIssues(rules, used)
+	}
+
+	for i, issue := range issues {
+		about := abouts[i]
+		r.emitIssueWith(issue, func(annotation *Annotation) bool { return canSuppressAnnotationIssue(annotation, about) })
+	}
+}
+
+// canSuppressAnnotationIssue returns whether the annotation can suppress the issue about the other annotation.
+// Only annotations naming the rule explicitly can suppress it, except for the issue about themselves,
+// so that unused annotations like `tflint-ignore: all` cannot hide themselves or each other.
+func canSuppressAnnotationIssue(annotation *Annotation, about *Annotation) bool {
+	if annotation == about {
+		return false
+	}
+	for _, name := range annotation.ruleNames() {
+		if name == (&unusedAnnotationRule{}).Name() {
+			return true
+		}
+	}
+	return false
+}
+
+// unusedAnnotationIssues returns the issues for unused annotations and unknown rule names in annotations,
+// and the annotations that the issues are about.
+func (r *Runner) unusedAnnotationIssues(rules map[string]bool, used map[*Annotation]bool) (Issues, []*Annotation) {
+	filenames := []string{}
+	for filename := range r.annotations {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	issues := Issues{}
+	abouts := []*Annotation{}
+	for _, filename := range filenames {
+		annotations := r.annotations[filename]
+		for i := range annotations {
+			annotation := &annotations[i]
+
+			known := false
+			for _, name := range annotation.ruleNames() {
+				if rules[name] {
+					known = true
+					continue
+				}
+				issues = append(issues, &Issue{
+					Rule:    &unusedAnnotationRule{},
+					Message: fmt.Sprintf("`%s` rule in the annotation does not exist in any enabled ruleset", name),
+					Range:   annotation.Token.Range,
+				})
+				abouts = append(abouts, annotation)
+			}
+
+			if known && !used[annotation] {
+				issues = append(issues, &Issue{
+					Rule:    &unusedAnnotationRule{},
+					Message: fmt.Sprintf("The annotation for `%s` did not suppress any issues", annotation.Content),
+					Range:   annotation.Token.Range,
+				})
+				abouts = append(abouts, annotation)
+			}
+		}
+	}
+	return issues, abouts
+}
+
+// markSuppressingAnnotations marks annotations that will suppress the passed issues as used,
+// in the same way as emitIssueWith. It returns whether any annotations are newly marked.
+func (r *Runner) markSuppressingAnnotations(issues Issues, abouts []*Annotation, used map[*Annotation]bool) bool {
+	marked := false
+	for i, issue := range issues {
+		if r.config.ExcludesPath(issue.Range.Filename) {
+			continue
+		}
+		if rule, exists := r.config.Rules[issue.Rule.Name()]; exists && !rule.MatchPath(issue.Range.Filename) {
+			continue
+		}
+		annotations := r.annotations[issue.Range.Filename]
+		for j := range annotations {
+			annotation := &annotations[j]
+			if !canSuppressAnnotationIssue(annotation, abouts[i]) || !annotation.IsAffected(issue) {
+				continue
+			}
+			if !used[annotation] {
+				used[annotation] = true
+				marked = true
+			}
+			break
+		}
+	}
+	return marked
 }
 
 // File returns the raw *hcl.File representation of a Terraform configuration at the specified path,
@@ -459,6 +665,13 @@ func (r *Runner) Sources() map[string][]byte {
 
 // EmitIssue builds an issue and accumulates it
 func (r *Runner) EmitIssue(rule Rule, message string, location hcl.Range) {
+	r.EmitIssueOnExpr(rule, message, location, r.currentExpr)
+}
+
+// EmitIssueOnExpr is the same as EmitIssue, except that the expression in which the issue is found
+// is passed explicitly instead of WithExpressionContext. Unlike WithExpressionContext, it is safe
+// for concurrent use.
+func (r *Runner) EmitIssueOnExpr(rule Rule, message string, location hcl.Range, expr hcl.Expression) {
 	if r.TFConfig.Path.IsRoot() {
 		r.emitIssue(&Issue{
 			Rule:    rule,
@@ -466,7 +679,7 @@ func (r *Runner) EmitIssue(rule Rule, message string, location hcl.Range) {
 			Range:   location,
 		})
 	} else {
-		for _, modVar := range r.listModuleVars(r.currentExpr) {
+		for _, modVar := range r.listModuleVars(expr) {
 			r.emitIssue(&Issue{
 				Rule:    rule,
 				Message: message,
@@ -477,7 +690,25 @@ func (r *Runner) EmitIssue(rule Rule, message string, location hcl.Range) {
 	}
 }
 
+// EmitIssueWithFix builds an issue with text edits that fix it and accumulates it.
+// Edits are only attached to issues in the root module, because issues of child modules
+// are reported in module calls and their source code cannot be changed mechanically.
+func (r *Runner) EmitIssueWithFix(rule Rule, message string, location hcl.Range, edits ...TextEdit) {
+	if !r.TFConfig.Path.IsRoot() {
+		r.EmitIssue(rule, message, location)
+		return
+	}
+
+	r.emitIssue(&Issue{
+		Rule:    rule,
+		Message: message,
+		Range:   location,
+		Edits:   edits,
+	})
+}
+
 // WithExpressionContext sets the context of the passed expression currently being processed.
+// It is not safe for concurrent use. Use EmitIssueOnExpr instead when rules are checked concurrently.
 func (r *Runner) WithExpressionContext(expr hcl.Expression, proc func() error) error {
 	r.currentExpr = expr
 	err := proc()
@@ -495,7 +726,7 @@ func (r *Runner) DecodeRuleConfig(ruleName string, val interface{}) error {
 				return errors.New("This rule cannot be enabled with the `--enable-rule` option because it lacks the required configuration")
 			}
 		} else {
-			diags := gohcl.DecodeBody(rule.Body, nil, val)
+			diags := gohcl.DecodeBody(rule.Body, configEvalContext(), val)
 			if diags.HasErrors() {
 				return diags
 			}
@@ -515,10 +746,34 @@ func (r *Runner) ConfigSources() map[string][]byte {
 }
 
 func (r *Runner) emitIssue(issue *Issue) {
+	r.emitIssueWith(issue, func(*Annotation) bool { return true })
+}
+
+// emitIssueWith is the same as emitIssue, except that only annotations for which canSuppress returns true
+// can suppress the issue. This prevents annotations from hiding the issues about themselves.
+func (r *Runner) emitIssueWith(issue *Issue, canSuppress func(*Annotation) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.config.ExcludesPath(issue.Range.Filename) {
+		log.Printf("[DEBUG] %s (%s) is ignored by exclude_paths", issue.Range.String(), issue.Rule.Name())
+		return
+	}
+	if rule, exists := r.config.Rules[issue.Rule.Name()]; exists && !rule.MatchPath(issue.Range.Filename) {
+		log.Printf("[DEBUG] %s (%s) is ignored by the include/exclude patterns of the rule", issue.Range.String(), issue.Rule.Name())
+		return
+	}
+	if severity := r.config.RuleSeverity(issue.Rule); severity != issue.Rule.Severity() {
+		issue.Rule = &severityOverriddenRule{Rule: issue.Rule, severity: severity}
+	}
 	if annotations, ok := r.annotations[issue.Range.Filename]; ok {
-		for _, annotation := range annotations {
-			if annotation.IsAffected(issue) {
+		for i := range annotations {
+			annotation := &annotations[i]
+			if canSuppress(annotation) && annotation.IsAffected(issue) {
 				log.Printf("[INFO] %s (%s) is ignored by %s", issue.Range.String(), issue.Rule.Name(), annotation.String())
+				r.affectedAnnotations[annotation] = true
+				issue.Suppression = &Suppression{Annotation: annotation}
+				r.SuppressedIssues = append(r.SuppressedIssues, issue)
 				return
 			}
 		}
@@ -528,7 +783,7 @@ func (r *Runner) emitIssue(issue *Issue) {
 
 func (r *Runner) listModuleVars(expr hcl.Expression) []*moduleVariable {
 	ret := []*moduleVariable{}
-	for _, ref := range listVarRefs(expr) {
+	for _, ref := range listReferencedVars(expr, r.TFConfig.Module.Locals) {
 		if modVar, exists := r.modVars[ref.Name]; exists {
 			ret = append(ret, modVar.roots()...)
 		}
@@ -586,3 +841,38 @@ func listVarRefs(expr hcl.Expression) map[string]addrs.InputVariable {
 
 	return ret
 }
+
+// listReferencedVars is similar to listVarRefs, but it also follows the local values referenced
+// in the expression and returns the input variables that they depend on.
+func listReferencedVars(expr hcl.Expression, locals map[string]*configs.Local) map[string]addrs.InputVariable {
+	ret := listVarRefs(expr)
+
+	visited := map[string]bool{}
+	queue := []hcl.Expression{expr}
+	for len(queue) > 0 {
+		refs, diags := lang.ReferencesInExpr(queue[0])
+		queue = queue[1:]
+		if diags.HasErrors() {
+			// Invalid references in local values are reported when evaluated
+			continue
+		}
+
+		for _, ref := range refs {
+			switch subject := ref.Subject.(type) {
+			case addrs.InputVariable:
+				ret[subject.String()] = subject
+			case addrs.LocalValue:
+				if visited[subject.Name] {
+					continue
+				}
+				visited[subject.Name] = true
+
+				if local, exists := locals[subject.Name]; exists {
+					queue = append(queue, local.Expr)
+				}
+			}
+		}
+	}
+
+	return ret
+}
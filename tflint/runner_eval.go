@@ -108,6 +108,8 @@ func isEvaluableRef(ref *addrs.Reference) bool {
 	switch ref.Subject.(type) {
 	case addrs.InputVariable:
 		return true
+	case addrs.LocalValue:
+		return true
 	case addrs.TerraformAttr:
 		return true
 	case addrs.PathAttr:
@@ -273,6 +273,88 @@ resource "null_resource" "test" {
 			Want:     `cty.ObjectVal(map[string]cty.Value{"one":cty.NumberIntVal(1), "two":cty.StringVal("2")})`,
 			ErrCheck: neverHappend,
 		},
+		{
+			Name: "local value",
+			Content: `
+variable "env" {
+  default = "production"
+}
+
+locals {
+  prefix = "app-${var.env}"
+  name   = "${local.prefix}-web"
+}
+
+resource "null_resource" "test" {
+  key = local.name
+}`,
+			Type:     cty.String,
+			Want:     `cty.StringVal("app-production-web")`,
+			ErrCheck: neverHappend,
+		},
+		{
+			Name: "local value depending on unevaluable references",
+			Content: `
+locals {
+  subnet = aws_subnet.app.id
+}
+
+resource "null_resource" "test" {
+  key = local.subnet
+}`,
+			Type: cty.String,
+			Want: `cty.NilVal`,
+			ErrCheck: func(err error) bool {
+				return err == nil || err.Error() != "unknown value found in main.tf:7" || !errors.Is(err, sdk.ErrUnknownValue)
+			},
+		},
+		{
+			Name: "local value depending on unevaluable references as cty.Value",
+			Content: `
+locals {
+  subnet = aws_subnet.app.id
+}
+
+resource "null_resource" "test" {
+  key = local.subnet
+}`,
+			Type:     cty.DynamicPseudoType,
+			Want:     `cty.DynamicVal`,
+			ErrCheck: neverHappend,
+		},
+		{
+			Name: "undefined local value",
+			Content: `
+locals {
+  name = "foo"
+}
+
+resource "null_resource" "test" {
+  key = local.nama
+}`,
+			Type: cty.String,
+			Want: `cty.NilVal`,
+			ErrCheck: func(err error) bool {
+				return err == nil || err.Error() != `failed to eval an expression in main.tf:7; Reference to undeclared local value: A local value with the name "nama" has not been declared. Did you mean "name"?`
+			},
+		},
+		{
+			Name: "circular local values",
+			Content: `
+locals {
+  a = local.b
+  b = local.a
+}
+
+resource "null_resource" "test" {
+  key = local.a
+}`,
+			Type: cty.String,
+			Want: `cty.NilVal`,
+			ErrCheck: func(err error) bool {
+				return err == nil || err.Error() != `failed to eval an expression in main.tf:8; Circular reference in local values: The local value "a" refers to itself: local.a -> local.b -> local.a.`
+			},
+		},
 		{
 			Name: "undefined variable",
 			Content: `
@@ -598,6 +680,14 @@ resource "null_resource" "test" {
     one = 1
     two = 2
   }
+}`,
+			Expected: true,
+		},
+		{
+			Name: "local value",
+			Content: `
+resource "null_resource" "test" {
+  key = local.name
 }`,
 			Expected: true,
 		},
@@ -694,6 +784,48 @@ resource "null_resource" "test" {
 	}
 }
 
+func Test_EvaluateExpr_localValueInOverrideFile(t *testing.T) {
+	runner := TestRunner(t, map[string]string{
+		"main.tf": `
+locals {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "web" {
+  instance_type = local.instance_type
+}`,
+		"main_override.tf": `
+locals {
+  instance_type = "m5.2xlarge"
+}`,
+	})
+
+	body, diags := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "instance_type"}},
+				},
+			},
+		},
+	}, sdk.GetModuleContentOption{})
+	if diags.HasErrors() {
+		t.Fatalf("failed to parse: %s", diags)
+	}
+
+	val, err := runner.EvaluateExpr(body.Blocks[0].Body.Attributes["instance_type"].Expr, cty.String)
+	if err != nil {
+		t.Fatalf("failed to eval: %s", err)
+	}
+
+	expected := `cty.StringVal("m5.2xlarge")`
+	if expected != val.GoString() {
+		t.Errorf("`%s` is expected, but got `%s`", expected, val.GoString())
+	}
+}
+
 func Test_overrideVariables(t *testing.T) {
 	cases := []struct {
 		Name        string
@@ -842,6 +974,10 @@ resource "null_resource" "test" {
 		{
 			Name: "count is unevaluable",
 			Content: `
+locals {
+  foo = aws_instance.main.id
+}
+
 resource "null_resource" "test" {
   count = local.foo
 }`,
@@ -901,6 +1037,10 @@ resource "null_resource" "test" {
 		{
 			Name: "for_each is unevaluable",
 			Content: `
+locals {
+  foo = aws_instance.main.id
+}
+
 resource "null_resource" "test" {
   for_each = local.foo
 }`,
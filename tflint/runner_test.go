@@ -3,7 +3,9 @@ package tflint
 import (
 	"errors"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -1206,6 +1208,407 @@ func Test_EmitIssue(t *testing.T) {
 	}
 }
 
+func Test_EmitIssue_severityOverride(t *testing.T) {
+	runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{})
+	runner.config.Rules["test_rule"] = &RuleConfig{
+		Name:     "test_rule",
+		Enabled:  true,
+		Severity: "notice",
+	}
+
+	runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}})
+
+	if len(runner.Issues) != 1 {
+		t.Fatalf("Expected 1 issue, but got %d", len(runner.Issues))
+	}
+	issue := runner.Issues[0]
+	if issue.Rule.Name() != "test_rule" {
+		t.Fatalf("Expected rule name is `test_rule`, but got `%s`", issue.Rule.Name())
+	}
+	if issue.Rule.Severity() != NOTICE {
+		t.Fatalf("Expected severity is `%s`, but got `%s`", NOTICE, issue.Rule.Severity())
+	}
+	if DefaultSeverity(issue.Rule) != ERROR {
+		t.Fatalf("Expected default severity is `%s`, but got `%s`", ERROR, DefaultSeverity(issue.Rule))
+	}
+}
+
+func Test_EmitIssue_pathFilter(t *testing.T) {
+	runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{})
+	runner.config.Rules["test_rule"] = &RuleConfig{
+		Name:    "test_rule",
+		Enabled: true,
+		Include: []string{"modules/**"},
+		Exclude: []string{"modules/examples/**"},
+	}
+
+	runner.EmitIssue(&testRule{}, "included", hcl.Range{Filename: "modules/vpc/main.tf", Start: hcl.Pos{Line: 1}})
+	runner.EmitIssue(&testRule{}, "not included", hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}})
+	runner.EmitIssue(&testRule{}, "excluded", hcl.Range{Filename: "modules/examples/main.tf", Start: hcl.Pos{Line: 1}})
+
+	if len(runner.Issues) != 1 || runner.Issues[0].Message != "included" {
+		t.Fatalf("Expected only the included issue, but got %#v", runner.Issues)
+	}
+}
+
+func Test_EmitIssue_suppressed(t *testing.T) {
+	runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{
+		"test.tf": {
+			{
+				Content: "test_rule",
+				Token: hclsyntax.Token{
+					Type:  hclsyntax.TokenComment,
+					Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}},
+				},
+				Reason: "legacy resource",
+			},
+		},
+	})
+
+	runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 2}})
+
+	if len(runner.Issues) != 0 {
+		t.Fatalf("Expected the issue is ignored, but got %d issues", len(runner.Issues))
+	}
+	suppressed := runner.LookupSuppressedIssues()
+	if len(suppressed) != 1 {
+		t.Fatalf("Expected 1 suppressed issue, but got %d", len(suppressed))
+	}
+	if suppressed[0].Suppression == nil {
+		t.Fatal("Expected the suppressed issue has a suppression")
+	}
+	if got := suppressed[0].Suppression.Justification(); got != "legacy resource" {
+		t.Fatalf("Expected justification is `legacy resource`, but got `%s`", got)
+	}
+	if got := runner.LookupSuppressedIssues("other.tf"); len(got) != 0 {
+		t.Fatalf("Expected no suppressed issues in other files, but got %d", len(got))
+	}
+}
+
+func Test_EmitIssue_concurrent(t *testing.T) {
+	runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(line int) {
+			defer wg.Done()
+			runner.EmitIssueOnExpr(&testRule{}, "This is test message", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: line}}, nil)
+		}(i + 1)
+	}
+	wg.Wait()
+
+	if got := len(runner.LookupIssues()); got != 10 {
+		t.Fatalf("Expected 10 issues, but got %d", got)
+	}
+}
+
+func Test_EmitInvalidAnnotations(t *testing.T) {
+	annotation := func(content string, line int, reason string, expires time.Time) Annotation {
+		return Annotation{
+			Content: content,
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenComment,
+				Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: line}},
+			},
+			Reason:  reason,
+			Expires: expires,
+		}
+	}
+	expired := time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local)
+	notExpired := time.Date(2999, 12, 31, 0, 0, 0, 0, time.Local)
+	annotations := map[string]Annotations{
+		"test.tf": {
+			annotation("rule1", 1, "legacy resource", time.Time{}),
+			annotation("rule2", 10, "", time.Time{}),
+			annotation("rule3", 20, "migrating", expired),
+			annotation("rule4", 30, "migrating", notExpired),
+			annotation("rule5", 40, "", expired),
+		},
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+
+	cases := []struct {
+		Name          string
+		RequireReason bool
+		ReportExpired bool
+		Config        func(*Config)
+		Expected      Issues
+	}{
+		{
+			Name:     "disabled",
+			Expected: Issues{},
+		},
+		{
+			Name:          "require reason",
+			RequireReason: true,
+			Expected: Issues{
+				{
+					Rule:    &annotationWithoutReasonRule{},
+					Message: "The annotation for `rule2` has no reason. Write it after \"--\"",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 10}},
+				},
+				{
+					Rule:    &annotationWithoutReasonRule{},
+					Message: "The annotation for `rule5` has no reason. Write it after \"--\"",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 40}},
+				},
+			},
+		},
+		{
+			Name:          "report expired",
+			ReportExpired: true,
+			Expected: Issues{
+				{
+					Rule:    &expiredAnnotationRule{},
+					Message: "The annotation for `rule3` expired on 2000-01-01",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 20}},
+				},
+				{
+					Rule:    &expiredAnnotationRule{},
+					Message: "The annotation for `rule5` expired on 2000-01-01",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 40}},
+				},
+			},
+		},
+		{
+			Name:          "rule config",
+			RequireReason: true,
+			ReportExpired: true,
+			Config: func(cfg *Config) {
+				cfg.Rules["tflint_annotation_without_reason"] = &RuleConfig{Name: "tflint_annotation_without_reason", Enabled: true, Severity: "warning"}
+				cfg.Rules["tflint_expired_annotation"] = &RuleConfig{Name: "tflint_expired_annotation", Enabled: true, Exclude: []string{"test.tf"}}
+			},
+			Expected: Issues{
+				{
+					Rule:    &severityOverriddenRule{Rule: &annotationWithoutReasonRule{}, severity: WARNING},
+					Message: "The annotation for `rule2` has no reason. Write it after \"--\"",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 10}},
+				},
+				{
+					Rule:    &severityOverriddenRule{Rule: &annotationWithoutReasonRule{}, severity: WARNING},
+					Message: "The annotation for `rule5` has no reason. Write it after \"--\"",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 40}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := testRunnerWithAnnotations(t, map[string]string{}, annotations)
+			runner.config.RequireAnnotationReason = tc.RequireReason
+			runner.config.ReportExpiredAnnotations = tc.ReportExpired
+			if tc.Config != nil {
+				tc.Config(runner.config)
+			}
+
+			runner.EmitInvalidAnnotations(now)
+			if diff := cmp.Diff(tc.Expected, runner.LookupIssues(), cmp.AllowUnexported(severityOverriddenRule{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_EmitInvalidAnnotations_all(t *testing.T) {
+	runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{
+		"test.tf": {
+			{
+				Content: "all",
+				Token: hclsyntax.Token{
+					Type:  hclsyntax.TokenComment,
+					Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}},
+				},
+				Expires: time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local),
+			},
+		},
+	})
+	runner.config.RequireAnnotationReason = true
+	runner.config.ReportExpiredAnnotations = true
+
+	runner.EmitInvalidAnnotations(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local))
+
+	expected := Issues{
+		{
+			Rule:    &annotationWithoutReasonRule{},
+			Message: "The annotation for `all` has no reason. Write it after \"--\"",
+			Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}},
+		},
+		{
+			Rule:    &expiredAnnotationRule{},
+			Message: "The annotation for `all` expired on 2020-01-01",
+			Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}},
+		},
+	}
+	if diff := cmp.Diff(expected, runner.LookupIssues()); diff != "" {
+		t.Fatal(diff)
+	}
+	if suppressed := runner.LookupSuppressedIssues(); len(suppressed) != 0 {
+		t.Fatalf("Expected no suppressed issues, but got %d issues", len(suppressed))
+	}
+}
+
+func Test_EmitUnusedAnnotations(t *testing.T) {
+	annotation := func(content string, filename string, line int) Annotation {
+		return Annotation{
+			Content: content,
+			Token: hclsyntax.Token{
+				Type: hclsyntax.TokenComment,
+				Range: hcl.Range{
+					Filename: filename,
+					Start:    hcl.Pos{Line: line},
+				},
+			},
+		}
+	}
+	annotations := map[string]Annotations{
+		"test.tf": {
+			annotation("test_rule", "test.tf", 1),
+			annotation("test_rule", "test.tf", 10),
+			annotation("unknown_rule", "test.tf", 20),
+			annotation("test_rule, unknown_rule", "test.tf", 30),
+			annotation("tflint_unused_annotation", "test.tf", 39),
+			annotation("test_rule", "test.tf", 40),
+		},
+		"test.generated.tf": {
+			annotation("test_rule", "test.generated.tf", 1),
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Config   func(*Config)
+		Expected Issues
+	}{
+		{
+			Name:   "default",
+			Config: func(*Config) {},
+			Expected: Issues{
+				{
+					Rule:    &unusedAnnotationRule{},
+					Message: "The annotation for `test_rule` did not suppress any issues",
+					Range:   hcl.Range{Filename: "test.generated.tf", Start: hcl.Pos{Line: 1}},
+				},
+				{
+					Rule:    &unusedAnnotationRule{},
+					Message: "The annotation for `test_rule` did not suppress any issues",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 10}},
+				},
+				{
+					Rule:    &unusedAnnotationRule{},
+					Message: "`unknown_rule` rule in the annotation does not exist in any enabled ruleset",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 20}},
+				},
+				{
+					Rule:    &unusedAnnotationRule{},
+					Message: "The annotation for `test_rule, unknown_rule` did not suppress any issues",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 30}},
+				},
+				{
+					Rule:    &unusedAnnotationRule{},
+					Message: "`unknown_rule` rule in the annotation does not exist in any enabled ruleset",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 30}},
+				},
+			},
+		},
+		{
+			Name: "exclude_paths and severity override",
+			Config: func(cfg *Config) {
+				cfg.ExcludePaths = []string{"*.generated.tf"}
+				cfg.Rules["tflint_unused_annotation"] = &RuleConfig{Name: "tflint_unused_annotation", Enabled: true, Severity: "error"}
+			},
+			Expected: Issues{
+				{
+					Rule:    &severityOverriddenRule{Rule: &unusedAnnotationRule{}, severity: ERROR},
+					Message: "The annotation for `test_rule` did not suppress any issues",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 10}},
+				},
+				{
+					Rule:    &severityOverriddenRule{Rule: &unusedAnnotationRule{}, severity: ERROR},
+					Message: "`unknown_rule` rule in the annotation does not exist in any enabled ruleset",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 20}},
+				},
+				{
+					Rule:    &severityOverriddenRule{Rule: &unusedAnnotationRule{}, severity: ERROR},
+					Message: "The annotation for `test_rule, unknown_rule` did not suppress any issues",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 30}},
+				},
+				{
+					Rule:    &severityOverriddenRule{Rule: &unusedAnnotationRule{}, severity: ERROR},
+					Message: "`unknown_rule` rule in the annotation does not exist in any enabled ruleset",
+					Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 30}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := testRunnerWithAnnotations(t, map[string]string{}, annotations)
+			tc.Config(runner.config)
+
+			runner.EmitIssue(&testRule{}, "This is test message", hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 2}})
+			if len(runner.Issues) != 0 {
+				t.Fatalf("Expected the issue is ignored, but got %d issues", len(runner.Issues))
+			}
+
+			runner.EmitUnusedAnnotations([]string{"test_rule"})
+			if diff := cmp.Diff(tc.Expected, runner.LookupIssues().Sort(), cmp.AllowUnexported(severityOverriddenRule{})); diff != "" {
+				t.Fatal(diff)
+			}
+
+			// The issue for the annotation on line 40 is suppressed by the annotation on line 39, so it is used
+			lines := []int{}
+			for _, issue := range runner.LookupSuppressedIssues().Sort() {
+				if issue.Rule.Name() == "tflint_unused_annotation" {
+					lines = append(lines, issue.Range.Start.Line)
+				}
+			}
+			if diff := cmp.Diff([]int{40}, lines); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_EmitUnusedAnnotations_all(t *testing.T) {
+	annotation := func(content string, annotationType AnnotationType, line int) Annotation {
+		return Annotation{
+			Content: content,
+			Type:    annotationType,
+			Token: hclsyntax.Token{
+				Type:  hclsyntax.TokenComment,
+				Range: hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: line}},
+			},
+		}
+	}
+	runner := testRunnerWithAnnotations(t, map[string]string{}, map[string]Annotations{
+		"test.tf": {
+			annotation("all", FileAnnotation, 1),
+			annotation("all", LineAnnotation, 10),
+		},
+	})
+
+	runner.EmitUnusedAnnotations([]string{"test_rule"})
+
+	expected := Issues{
+		{
+			Rule:    &unusedAnnotationRule{},
+			Message: "The annotation for `all` did not suppress any issues",
+			Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 1}},
+		},
+		{
+			Rule:    &unusedAnnotationRule{},
+			Message: "The annotation for `all` did not suppress any issues",
+			Range:   hcl.Range{Filename: "test.tf", Start: hcl.Pos{Line: 10}},
+		},
+	}
+	if diff := cmp.Diff(expected, runner.LookupIssues().Sort()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func Test_DecodeRuleConfig(t *testing.T) {
 	type ruleSchema struct {
 		Foo string `hcl:"foo"`
@@ -1235,6 +1638,37 @@ func Test_DecodeRuleConfig(t *testing.T) {
 	}
 }
 
+func Test_DecodeRuleConfig_evalContext(t *testing.T) {
+	t.Setenv("TFLINT_TEST_ENV", "Production")
+
+	type ruleSchema struct {
+		Foo string `hcl:"foo"`
+	}
+	options := ruleSchema{}
+
+	file, diags := hclsyntax.ParseConfig([]byte(`foo = lower(env("TFLINT_TEST_ENV"))`), "test.hcl", hcl.Pos{})
+	if diags.HasErrors() {
+		t.Fatalf("Failed to parse test config: %s", diags)
+	}
+
+	cfg := EmptyConfig()
+	cfg.Rules["test"] = &RuleConfig{
+		Name:    "test",
+		Enabled: true,
+		Body:    file.Body,
+	}
+
+	runner := TestRunnerWithConfig(t, map[string]string{}, cfg)
+	if err := runner.DecodeRuleConfig("test", &options); err != nil {
+		t.Fatalf("Failed to decode rule config: %s", err)
+	}
+
+	expected := ruleSchema{Foo: "production"}
+	if !cmp.Equal(options, expected) {
+		t.Fatalf("Failed to decode rule config: diff=%s", cmp.Diff(options, expected))
+	}
+}
+
 func Test_DecodeRuleConfig_emptyBody(t *testing.T) {
 	type ruleSchema struct {
 		Foo string `hcl:"foo"`
@@ -1341,3 +1775,60 @@ func Test_listVarRefs(t *testing.T) {
 		}
 	}
 }
+
+func Test_listReferencedVars(t *testing.T) {
+	runner := TestRunner(t, map[string]string{"main.tf": `
+locals {
+  name   = "${local.prefix}-${var.name}"
+  prefix = var.prefix
+  loop   = local.loop
+  tags   = { Name = local.name }
+}`})
+	locals := runner.TFConfig.Module.Locals
+
+	cases := []struct {
+		Name     string
+		Expr     string
+		Expected map[string]addrs.InputVariable
+	}{
+		{
+			Name: "input variable",
+			Expr: "var.foo",
+			Expected: map[string]addrs.InputVariable{
+				"var.foo": {Name: "foo"},
+			},
+		},
+		{
+			Name: "nested local values",
+			Expr: "local.tags",
+			Expected: map[string]addrs.InputVariable{
+				"var.name":   {Name: "name"},
+				"var.prefix": {Name: "prefix"},
+			},
+		},
+		{
+			Name:     "circular local value",
+			Expr:     "local.loop",
+			Expected: map[string]addrs.InputVariable{},
+		},
+		{
+			Name:     "undefined local value",
+			Expr:     "local.undefined",
+			Expected: map[string]addrs.InputVariable{},
+		},
+	}
+
+	for _, tc := range cases {
+		expr, diags := hclsyntax.ParseExpression([]byte(tc.Expr), "template.tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+
+		refs := listReferencedVars(expr, locals)
+
+		opt := cmpopts.IgnoreUnexported(addrs.InputVariable{})
+		if !cmp.Equal(tc.Expected, refs, opt) {
+			t.Fatalf("%s: Diff=%s", tc.Name, cmp.Diff(tc.Expected, refs, opt))
+		}
+	}
+}